@@ -0,0 +1,325 @@
+package mysubaru
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Command Approval
+// =============================================================================
+
+// DefaultApprovalTimeout is how long a command waits for its approval.
+const DefaultApprovalTimeout = 5 * time.Minute
+
+// ApprovalDecision is the outcome of an approval request.
+type ApprovalDecision string
+
+const (
+	ApprovalApproved ApprovalDecision = "approve"
+	ApprovalDenied   ApprovalDecision = "deny"
+	ApprovalTimedOut ApprovalDecision = "timeout"
+)
+
+// ApprovalTarget designates a command that needs approval. An empty Action
+// matches every action of Command.
+type ApprovalTarget struct {
+	Command RemoteCommand
+	Action  string
+}
+
+var (
+	// ApproveUnlock designates unlocking the doors.
+	ApproveUnlock = ApprovalTarget{Command: CommandUnlock, Action: "execute"}
+	// ApproveValetModeStop designates turning valet mode off.
+	ApproveValetModeStop = ApprovalTarget{Command: CommandValetMode, Action: "stop"}
+)
+
+// matches reports whether t designates req.
+func (t ApprovalTarget) matches(req CommandRequest) bool {
+	return t.Command == req.Command && (t.Action == "" || t.Action == req.Action)
+}
+
+// ApprovalRequest asks an Approver whether a command may be sent.
+type ApprovalRequest struct {
+	ID string `json:"id"` // unique and unguessable; callbacks quote it
+	CommandRequest
+	Requester string `json:"requester"` // who asked for the command
+}
+
+// Approver decides whether a designated command may be sent. Approve blocks
+// until it has a decision or ctx is done, in which case it returns
+// ApprovalTimedOut. An error denies the command.
+type Approver interface {
+	Approve(ctx context.Context, req ApprovalRequest) (ApprovalDecision, error)
+}
+
+// ApprovalOptions configures when and how a client asks its Approver.
+type ApprovalOptions struct {
+	// Commands lists the commands needing approval; unlock and valet mode
+	// stop when empty.
+	Commands []ApprovalTarget
+	// Timeout bounds each approval; DefaultApprovalTimeout when zero.
+	Timeout time.Duration
+	// Requester identifies the client to the approver unless the command's
+	// context carries one from WithRequester.
+	Requester string
+}
+
+// approvalGate is an Approver with the options it was set up with.
+type approvalGate struct {
+	approver Approver
+	opts     ApprovalOptions
+}
+
+// SetApprover makes designated remote commands of the client wait for a's
+// approval before they are sent. A nil a removes the approver.
+func (c *Client) SetApprover(a Approver, opts ApprovalOptions) {
+	if a == nil {
+		c.approval.Store(nil)
+		return
+	}
+	if len(opts.Commands) == 0 {
+		opts.Commands = []ApprovalTarget{ApproveUnlock, ApproveValetModeStop}
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultApprovalTimeout
+	}
+	c.approval.Store(&approvalGate{approver: a, opts: opts})
+}
+
+type requesterKey struct{}
+
+// WithRequester returns a copy of ctx naming who requests the commands sent
+// with it, for approvers to show.
+func WithRequester(ctx context.Context, requester string) context.Context {
+	return context.WithValue(ctx, requesterKey{}, requester)
+}
+
+// approve asks the gate's approver about req when it is designated, returning
+// ErrApprovalDenied or ErrApprovalTimeout unless it is approved.
+func (g *approvalGate) approve(ctx context.Context, req CommandRequest, logger *slog.Logger) error {
+	if !slices.ContainsFunc(g.opts.Commands, func(t ApprovalTarget) bool { return t.matches(req) }) {
+		return nil
+	}
+	id, err := newApprovalID()
+	if err != nil {
+		return err
+	}
+	ar := ApprovalRequest{ID: id, CommandRequest: req, Requester: g.opts.Requester}
+	if r, ok := ctx.Value(requesterKey{}).(string); ok {
+		ar.Requester = r
+	}
+
+	actx, cancel := context.WithTimeout(ctx, g.opts.Timeout)
+	defer cancel()
+	decision, err := g.approver.Approve(actx, ar)
+	if err != nil {
+		logger.Error("approval request failed", "vin", req.Vin, "command", req.Command, "error", err.Error())
+		return fmt.Errorf("approval request failed: %w", err)
+	}
+	logger.Info("approval decided", "vin", req.Vin, "command", req.Command, "action", req.Action, "requester", ar.Requester, "decision", decision)
+
+	switch decision {
+	case ApprovalApproved:
+		return nil
+	case ApprovalTimedOut:
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return ErrApprovalTimeout
+	default:
+		return ErrApprovalDenied
+	}
+}
+
+// newApprovalID returns a random approval request ID.
+func newApprovalID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generating approval ID: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// =============================================================================
+// Channel Approver
+// =============================================================================
+
+// PendingApproval is an approval request waiting for a ChannelApprover
+// consumer's decision.
+type PendingApproval struct {
+	Request ApprovalRequest
+	reply   chan ApprovalDecision
+}
+
+// Approve approves the request. Only the first decision counts.
+func (p *PendingApproval) Approve() { p.decide(ApprovalApproved) }
+
+// Deny denies the request. Only the first decision counts.
+func (p *PendingApproval) Deny() { p.decide(ApprovalDenied) }
+
+func (p *PendingApproval) decide(d ApprovalDecision) {
+	select {
+	case p.reply <- d:
+	default:
+	}
+}
+
+// ChannelApprover hands approval requests to a consumer reading Requests,
+// such as a bot relaying them to a chat.
+type ChannelApprover struct {
+	requests chan *PendingApproval
+}
+
+// NewChannelApprover returns a ChannelApprover queueing up to buffer requests
+// for its consumer.
+func NewChannelApprover(buffer int) *ChannelApprover {
+	return &ChannelApprover{requests: make(chan *PendingApproval, buffer)}
+}
+
+// Requests returns the channel approval requests arrive on. Each must be
+// approved or denied before its timeout.
+func (a *ChannelApprover) Requests() <-chan *PendingApproval {
+	return a.requests
+}
+
+// Approve queues req for the consumer and waits for its decision.
+func (a *ChannelApprover) Approve(ctx context.Context, req ApprovalRequest) (ApprovalDecision, error) {
+	p := &PendingApproval{Request: req, reply: make(chan ApprovalDecision, 1)}
+	select {
+	case a.requests <- p:
+	case <-ctx.Done():
+		return ApprovalTimedOut, nil
+	}
+	select {
+	case d := <-p.reply:
+		return d, nil
+	case <-ctx.Done():
+		return ApprovalTimedOut, nil
+	}
+}
+
+// =============================================================================
+// HTTP Callback Approver
+// =============================================================================
+
+// approvalCallback is the body of an HTTPApprover webhook answer or callback.
+type approvalCallback struct {
+	ID       string           `json:"id"`
+	Decision ApprovalDecision `json:"decision"`
+}
+
+// HTTPApprover posts each approval request as JSON to a webhook, for example
+// one sending a phone notification. The decision comes back either in the
+// webhook's response, as {"decision": "approve"}, or later as a POST of
+// {"id": "...", "decision": "approve" | "deny"} to the approver, which is an
+// http.Handler to mount on the callback URL.
+type HTTPApprover struct {
+	webhookURL string
+	httpClient *http.Client
+
+	mu      sync.Mutex
+	pending map[string]chan ApprovalDecision
+}
+
+// NewHTTPApprover returns an HTTPApprover posting to webhookURL with
+// httpClient (http.DefaultClient when nil).
+func NewHTTPApprover(webhookURL string, httpClient *http.Client) *HTTPApprover {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &HTTPApprover{
+		webhookURL: webhookURL,
+		httpClient: httpClient,
+		pending:    make(map[string]chan ApprovalDecision),
+	}
+}
+
+// Approve posts req to the webhook and waits for the decision.
+func (a *HTTPApprover) Approve(ctx context.Context, req ApprovalRequest) (ApprovalDecision, error) {
+	reply := make(chan ApprovalDecision, 1)
+	a.mu.Lock()
+	a.pending[req.ID] = reply
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		delete(a.pending, req.ID)
+		a.mu.Unlock()
+	}()
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, a.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+	resp, err := a.httpClient.Do(hreq)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ApprovalTimedOut, nil
+		}
+		return "", fmt.Errorf("approval webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("approval webhook returned %s", resp.Status)
+	}
+	var answer approvalCallback
+	if b, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<16)); len(bytes.TrimSpace(b)) > 0 {
+		if err := json.Unmarshal(b, &answer); err != nil {
+			return "", fmt.Errorf("approval webhook answer: %w", err)
+		}
+	}
+	if answer.Decision == ApprovalApproved || answer.Decision == ApprovalDenied {
+		return answer.Decision, nil
+	}
+
+	select {
+	case d := <-reply:
+		return d, nil
+	case <-ctx.Done():
+		return ApprovalTimedOut, nil
+	}
+}
+
+// ServeHTTP receives decision callbacks. It answers 404 for unknown or
+// expired request IDs.
+func (a *HTTPApprover) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var cb approvalCallback
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<16)).Decode(&cb); err != nil {
+		http.Error(w, "invalid callback body", http.StatusBadRequest)
+		return
+	}
+	if cb.Decision != ApprovalApproved && cb.Decision != ApprovalDenied {
+		http.Error(w, "decision must be approve or deny", http.StatusBadRequest)
+		return
+	}
+	a.mu.Lock()
+	reply, ok := a.pending[cb.ID]
+	delete(a.pending, cb.ID)
+	a.mu.Unlock()
+	if !ok {
+		http.Error(w, "unknown approval request", http.StatusNotFound)
+		return
+	}
+	reply <- cb.Decision
+	w.WriteHeader(http.StatusNoContent)
+}
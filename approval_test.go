@@ -0,0 +1,120 @@
+package mysubaru
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestChannelApprover verifies designated commands wait for the consumer's
+// decision and other commands pass straight through.
+func TestChannelApprover(t *testing.T) {
+	v, _ := setupCountingVehicle(t, true)
+	a := NewChannelApprover(1)
+	v.client.SetApprover(a, ApprovalOptions{Requester: "automation"})
+	ctx := WithRequester(context.Background(), "garage-door")
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := v.Unlock(ctx)
+		done <- err
+	}()
+	p := <-a.Requests()
+	if p.Request.Command != CommandUnlock || p.Request.Vin != v.Vin || p.Request.Requester != "garage-door" || p.Request.ID == "" {
+		t.Errorf("unexpected approval request: %+v", p.Request)
+	}
+	if p.Request.Location.Lat != v.GeoLocation.Latitude || p.Request.Params["pin"] != "[REDACTED]" {
+		t.Errorf("expected location and redacted params, got %+v", p.Request)
+	}
+	p.Approve()
+	if err := <-done; err != nil {
+		t.Errorf("expected approved unlock to succeed, got %v", err)
+	}
+
+	go func() {
+		_, err := v.ValetModeStop(context.Background())
+		done <- err
+	}()
+	p = <-a.Requests()
+	if p.Request.Command != CommandValetMode || p.Request.Action != "stop" || p.Request.Requester != "automation" {
+		t.Errorf("unexpected approval request: %+v", p.Request)
+	}
+	p.Deny()
+	if err := <-done; !errors.Is(err, ErrApprovalDenied) {
+		t.Errorf("expected ErrApprovalDenied, got %v", err)
+	}
+
+	if _, err := v.Lock(ctx); err != nil {
+		t.Errorf("expected lock not to need approval, got %v", err)
+	}
+	if _, err := v.ValetModeStart(ctx); err != nil {
+		t.Errorf("expected valet mode start not to need approval, got %v", err)
+	}
+	select {
+	case p := <-a.Requests():
+		t.Errorf("expected no approval request, got %+v", p.Request)
+	default:
+	}
+}
+
+// TestApprovalTimeout verifies an unanswered approval fails the command.
+func TestApprovalTimeout(t *testing.T) {
+	v, _ := setupCountingVehicle(t, true)
+	v.client.SetApprover(NewChannelApprover(1), ApprovalOptions{Timeout: 20 * time.Millisecond})
+
+	if err := v.client.RemoteUnlock(context.Background(), v.Vin); !errors.Is(err, ErrApprovalTimeout) {
+		t.Errorf("expected ErrApprovalTimeout, got %v", err)
+	}
+	if n := len(v.client.DryRunLog().Commands()); n != 0 {
+		t.Errorf("expected the unapproved unlock not to be recorded, got %d", n)
+	}
+}
+
+// TestHTTPApprover verifies decisions arriving in the webhook answer and by
+// callback.
+func TestHTTPApprover(t *testing.T) {
+	var a *HTTPApprover
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ApprovalRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+		if req.Requester == "stranger" {
+			w.Write([]byte(`{"decision":"deny"}`))
+			return
+		}
+		// Answer later, as a phone would.
+		go func() {
+			rec := httptest.NewRecorder()
+			a.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/approvals", strings.NewReader(`{"id":"`+req.ID+`","decision":"approve"}`)))
+			if rec.Code != http.StatusNoContent {
+				t.Errorf("expected callback status 204, got %d", rec.Code)
+			}
+		}()
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer webhook.Close()
+	a = NewHTTPApprover(webhook.URL, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req := ApprovalRequest{ID: "1", CommandRequest: CommandRequest{Command: CommandUnlock, Action: "execute"}, Requester: "owner"}
+	if d, err := a.Approve(ctx, req); err != nil || d != ApprovalApproved {
+		t.Errorf("expected approval by callback, got %q, %v", d, err)
+	}
+	req.ID, req.Requester = "2", "stranger"
+	if d, err := a.Approve(ctx, req); err != nil || d != ApprovalDenied {
+		t.Errorf("expected denial in the webhook answer, got %q, %v", d, err)
+	}
+
+	rec := httptest.NewRecorder()
+	a.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/approvals", strings.NewReader(`{"id":"1","decision":"approve"}`)))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for a settled request, got %d", rec.Code)
+	}
+}
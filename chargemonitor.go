@@ -0,0 +1,237 @@
+package mysubaru
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultChargeMonitorInterval     = 5 * time.Minute
+	DefaultChargeMonitorIdleInterval = 15 * time.Minute
+
+	// DefaultPHEVBatteryKWh is the usable battery capacity used for energy
+	// estimates when ChargeMonitorOptions.BatteryKWh is not set.
+	DefaultPHEVBatteryKWh = 8.8
+)
+
+// ChargeEventType is the kind of change a ChargeMonitor reports.
+type ChargeEventType string
+
+const (
+	ChargeStarted       ChargeEventType = "started"        // charging began
+	ChargeInterrupted   ChargeEventType = "interrupted"    // charging stopped before completion while still plugged in
+	ChargeUnplugged     ChargeEventType = "unplugged"      // the vehicle was unplugged during a session
+	ChargeTargetReached ChargeEventType = "target_reached" // state of charge reached the target
+	ChargeCompleted     ChargeEventType = "completed"      // charging finished
+)
+
+// ChargeEvent is a charge session change observed by a ChargeMonitor.
+type ChargeEvent struct {
+	Type                 ChargeEventType
+	Time                 time.Time
+	StateOfChargePercent int
+	EstimatedCompletion  time.Time      // zero when unknown
+	Session              *ChargeSession // the ended session, set on interrupted/unplugged/completed
+	Err                  error          // polling error; Type is empty
+}
+
+// ChargeSession is one recorded charging session.
+type ChargeSession struct {
+	Start, End     time.Time
+	StartSoC       int // state of charge, percent
+	EndSoC         int
+	EnergyAddedKWh float64 // estimate: SoC gained times battery capacity
+	Ended          ChargeEventType
+}
+
+// ChargeMonitorOptions configures a ChargeMonitor. Zero values use the defaults.
+type ChargeMonitorOptions struct {
+	Interval     time.Duration // poll interval while plugged in, default 5m
+	IdleInterval time.Duration // poll interval while unplugged, default 15m
+	TargetSoC    int           // target state of charge in percent, default 100
+	BatteryKWh   float64       // usable battery capacity, default DefaultPHEVBatteryKWh
+}
+
+// ChargeMonitor follows PHEV charging sessions by polling the vehicle
+// condition, and reports session events and records.
+type ChargeMonitor struct {
+	v    *Vehicle
+	opts ChargeMonitorOptions
+	now  func() time.Time
+
+	mu       sync.Mutex
+	current  *ChargeSession
+	target   bool // target reached reported for the current session
+	sessions []ChargeSession
+}
+
+// NewChargeMonitor returns a charge monitor for the PHEV v.
+func NewChargeMonitor(v *Vehicle, opts ChargeMonitorOptions) (*ChargeMonitor, error) {
+	if !v.IsEV() {
+		return nil, errors.New("vehicle is not an EV")
+	}
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultChargeMonitorInterval
+	}
+	if opts.IdleInterval <= 0 {
+		opts.IdleInterval = DefaultChargeMonitorIdleInterval
+	}
+	if opts.TargetSoC <= 0 || opts.TargetSoC > 100 {
+		opts.TargetSoC = 100
+	}
+	if opts.BatteryKWh <= 0 {
+		opts.BatteryKWh = DefaultPHEVBatteryKWh
+	}
+	return &ChargeMonitor{v: v, opts: opts, now: time.Now}, nil
+}
+
+// Sessions returns the sessions recorded so far, oldest first.
+func (m *ChargeMonitor) Sessions() []ChargeSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.sessions)
+}
+
+// Run polls GetVehicleCondition until ctx is cancelled: every Interval while
+// the vehicle is plugged in, every IdleInterval otherwise. Events are sent on
+// the returned channel, which is closed when Run stops.
+func (m *ChargeMonitor) Run(ctx context.Context) <-chan ChargeEvent {
+	ch := make(chan ChargeEvent, 8)
+	go func() {
+		defer close(ch)
+		for {
+			var events []ChargeEvent
+			if err := m.v.GetVehicleCondition(ctx); err != nil {
+				events = []ChargeEvent{{Time: m.now(), Err: err}}
+			} else {
+				events = m.observe(m.now())
+			}
+			for _, ev := range events {
+				select {
+				case ch <- ev:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			m.v.mu.RLock()
+			plugged := m.v.EVStatus.IsPluggedIn
+			m.v.mu.RUnlock()
+			wait := m.opts.IdleInterval
+			if plugged {
+				wait = m.opts.Interval
+			}
+			if sleepCtx(ctx, wait) != nil {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// observe compares the vehicle's current EV status with the running session
+// and returns the resulting events.
+func (m *ChargeMonitor) observe(now time.Time) []ChargeEvent {
+	m.v.mu.RLock()
+	plugged := m.v.EVStatus.IsPluggedIn
+	charging := m.v.EVStatus.ChargerStateType == ChargerCharging
+	soc := m.v.EVStatus.StateOfChargePercent
+	m.v.mu.RUnlock()
+	eta, _ := m.v.EstimatedChargeCompletion(now)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	event := func(t ChargeEventType) ChargeEvent {
+		return ChargeEvent{Type: t, Time: now, StateOfChargePercent: soc, EstimatedCompletion: eta}
+	}
+	var events []ChargeEvent
+
+	if m.current == nil {
+		if charging && plugged {
+			m.current = &ChargeSession{Start: now, StartSoC: soc}
+			m.target = false
+			events = append(events, event(ChargeStarted))
+		} else {
+			return nil
+		}
+	}
+
+	if !m.target && soc >= m.opts.TargetSoC {
+		m.target = true
+		events = append(events, event(ChargeTargetReached))
+	}
+
+	var ended ChargeEventType
+	switch {
+	case !plugged:
+		ended = ChargeUnplugged
+	case !charging && soc >= m.opts.TargetSoC:
+		ended = ChargeCompleted
+	case !charging:
+		ended = ChargeInterrupted
+	default:
+		return events
+	}
+
+	s := *m.current
+	s.End, s.EndSoC, s.Ended = now, soc, ended
+	if gained := s.EndSoC - s.StartSoC; gained > 0 {
+		s.EnergyAddedKWh = float64(gained) / 100 * m.opts.BatteryKWh
+	}
+	m.sessions = append(m.sessions, s)
+	m.current = nil
+
+	ev := event(ended)
+	ev.Session = &s
+	return append(events, ev)
+}
+
+// EstimatedChargeCompletion returns when the battery is expected to be fully
+// charged, from EV_TIME_TO_FULLY_CHARGED_UTC when reported, otherwise from the
+// minutes in EV_TIME_TO_FULLY_CHARGED counted from now. It reports false when
+// neither holds a valid value (including BAD_EV_TIME_TO_FULLY_CHARGED).
+func (v *Vehicle) EstimatedChargeCompletion(now time.Time) (time.Time, bool) {
+	v.mu.RLock()
+	minutes := strings.TrimSpace(v.EVStatus.TimeToFullyCharged)
+	utc := strings.TrimSpace(v.EVStatus.TimeToFullyChargedUTC)
+	v.mu.RUnlock()
+
+	for _, layout := range []string{"2006-01-02T15:04:05-0700", "2006-01-02T15:04:05.000-0700", time.RFC3339} {
+		if t, err := time.Parse(layout, utc); err == nil {
+			return t, true
+		}
+	}
+	if minutes == "" || minutes == BAD_EV_TIME_TO_FULLY_CHARGED {
+		return time.Time{}, false
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m < 0 {
+		return time.Time{}, false
+	}
+	return now.Add(time.Duration(m) * time.Minute), true
+}
+
+// evPluggedIn interprets evIsPluggedIn, which the API reports as a connection
+// state string (LOCKED_CONNECTED, UNLOCKED_CONNECTED, NOT_CONNECTED) or a bool.
+func evPluggedIn(raw json.RawMessage) bool {
+	var b bool
+	if err := json.Unmarshal(raw, &b); err == nil {
+		return b
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return false
+	}
+	switch strings.ToUpper(s) {
+	case LOCKED_CONNECTED, UNLOCKED_CONNECTED, "TRUE":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package mysubaru
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestEVPluggedIn covers the connection state strings and bools the API sends.
+func TestEVPluggedIn(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want bool
+	}{
+		{`"UNLOCKED_CONNECTED"`, true},
+		{`"LOCKED_CONNECTED"`, true},
+		{`"NOT_CONNECTED"`, false},
+		{`true`, true},
+		{`false`, false},
+		{`null`, false},
+		{``, false},
+	}
+	for _, tt := range tests {
+		if got := evPluggedIn(json.RawMessage(tt.raw)); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.raw, tt.want, got)
+		}
+	}
+
+	var vc VehicleCondition
+	if err := json.Unmarshal([]byte(`{"evIsPluggedIn":"UNLOCKED_CONNECTED","evStateOfChargePercent":"60"}`), &vc); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !evPluggedIn(vc.EvIsPluggedIn) || vc.EvStateOfChargePercent != "60" {
+		t.Errorf("unexpected condition: %+v", vc)
+	}
+}
+
+// TestEstimatedChargeCompletion covers the UTC timestamp, the minutes fallback
+// and the bad value.
+func TestEstimatedChargeCompletion(t *testing.T) {
+	now := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+	v := &Vehicle{}
+
+	v.EVStatus.TimeToFullyCharged = "90"
+	if eta, ok := v.EstimatedChargeCompletion(now); !ok || !eta.Equal(now.Add(90*time.Minute)) {
+		t.Errorf("expected %v, got %v (%v)", now.Add(90*time.Minute), eta, ok)
+	}
+
+	v.EVStatus.TimeToFullyChargedUTC = "2026-03-01T22:15:00+0000"
+	if eta, ok := v.EstimatedChargeCompletion(now); !ok || !eta.Equal(time.Date(2026, 3, 1, 22, 15, 0, 0, time.UTC)) {
+		t.Errorf("expected UTC timestamp to win, got %v (%v)", eta, ok)
+	}
+
+	v.EVStatus.TimeToFullyChargedUTC = ""
+	v.EVStatus.TimeToFullyCharged = BAD_EV_TIME_TO_FULLY_CHARGED
+	if _, ok := v.EstimatedChargeCompletion(now); ok {
+		t.Errorf("expected %s to give no estimate", BAD_EV_TIME_TO_FULLY_CHARGED)
+	}
+}
+
+// TestChargeMonitor_Sessions walks the monitor through a completed session and
+// an unplugged one.
+func TestChargeMonitor_Sessions(t *testing.T) {
+	v := &Vehicle{Features: []string{"g2", FEATURE_PHEV}}
+	m, err := NewChargeMonitor(v, ChargeMonitorOptions{TargetSoC: 80, BatteryKWh: 10})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	now := time.Date(2026, 3, 1, 20, 0, 0, 0, time.UTC)
+	step := func(plugged bool, state ChargerState, soc int) []ChargeEventType {
+		now = now.Add(5 * time.Minute)
+		v.EVStatus.IsPluggedIn = plugged
+		v.EVStatus.ChargerStateType = state
+		v.EVStatus.StateOfChargePercent = soc
+		var types []ChargeEventType
+		for _, ev := range m.observe(now) {
+			types = append(types, ev.Type)
+		}
+		return types
+	}
+	expect := func(got []ChargeEventType, want ...ChargeEventType) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+		}
+	}
+
+	expect(step(false, "", 40))
+	expect(step(true, CHARGING, 40), ChargeStarted)
+	expect(step(true, CHARGING, 70))
+	expect(step(true, CHARGING, 85), ChargeTargetReached)
+	expect(step(true, "CHARGING_STOPPED", 100), ChargeCompleted)
+	expect(step(true, "CHARGING_STOPPED", 100))
+
+	expect(step(true, CHARGING, 50), ChargeStarted)
+	expect(step(false, "", 55), ChargeUnplugged)
+
+	sessions := m.Sessions()
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+	if s := sessions[0]; s.StartSoC != 40 || s.EndSoC != 100 || s.EnergyAddedKWh != 6 || s.Ended != ChargeCompleted {
+		t.Errorf("unexpected first session: %+v", s)
+	}
+	if s := sessions[1]; s.Ended != ChargeUnplugged || s.EnergyAddedKWh != 0.5 {
+		t.Errorf("unexpected second session: %+v", s)
+	}
+
+	if _, err := NewChargeMonitor(&Vehicle{Features: []string{"g2"}}, ChargeMonitorOptions{}); err == nil {
+		t.Error("expected error for non-EV vehicle")
+	}
+}
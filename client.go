@@ -67,6 +67,7 @@ type Client struct {
 	apiBumps       atomic.Int32
 	updateInterval int // seconds, DEFAULT_UPDATE_INTERVAL
 	fetchInterval  int // seconds, DEFAULT_FETCH_INTERVAL
+	units          UnitSystem
 	logger         *slog.Logger
 	metrics        config.MetricsRecorder
 	// baseURL is the resolved API host: the config override when set, otherwise
@@ -81,6 +82,19 @@ type Client struct {
 	// so requests are deliberately one-at-a-time. It also guards httpClient,
 	// which resetSession swaps for a fresh cookie jar.
 	reqMu sync.RWMutex
+	// dryRun records remote commands instead of sending them when the client
+	// is configured for dry runs; nil otherwise.
+	dryRun *DryRunLog
+	// policy, when set, vets every remote command before it is sent.
+	policy atomic.Pointer[PolicyEngine]
+	// approval, when set, asks an Approver before designated commands.
+	approval atomic.Pointer[approvalGate]
+	// pin tracks remote-services PIN failures and lockouts.
+	pin pinGuard
+	// pinSupplier, when set, provides the PIN instead of credentials.PIN.
+	pinSupplier atomic.Pointer[pinSource]
+	// events fans vehicle state changes out to subscribers.
+	events eventBus
 }
 
 // session-state accessors (guarded by stateMu) ------------------------------
@@ -207,15 +221,23 @@ func New(config *config.Config) (*Client, error) {
 	if metrics == nil {
 		metrics = &NoOpMetricsRecorder{}
 	}
+	units, err := ParseUnitSystem(config.Units, config.MySubaru.Region)
+	if err != nil {
+		return nil, err
+	}
 
 	client := &Client{
 		credentials:    config.MySubaru.Credentials,
 		country:        config.MySubaru.Region,
 		updateInterval: DEFAULT_UPDATE_INTERVAL,
 		fetchInterval:  DEFAULT_FETCH_INTERVAL,
+		units:          units,
 		logger:         config.Logger,
 		metrics:        metrics,
 	}
+	if config.MySubaru.DryRun {
+		client.dryRun = &DryRunLog{}
+	}
 	client.baseURL = config.MySubaru.BaseURL
 	if client.baseURL == "" {
 		client.baseURL = mobileAPIServer[client.country]
@@ -378,13 +400,14 @@ func (c *Client) GetVehicleByVin(ctx context.Context, vin string) (*Vehicle, err
 			LicensePlateState:    vd.LicensePlateState,
 			Features:             vd.Features,
 			SubscriptionFeatures: vd.SubscriptionFeatures,
+			TimeZone:             vd.TimeZone,
+			StolenVehicle:        vd.StolenVehicle,
 			client:               c,
 		}
 		vehicle.Doors = make(map[string]Door)
 		vehicle.Windows = make(map[string]Window)
 		vehicle.Tires = make(map[string]Tire)
 		vehicle.ClimateProfiles = make(map[string]ClimateProfile)
-		vehicle.Troubles = make(map[string]Trouble)
 
 		// Populate vehicle state - log errors but don't fail the entire vehicle creation
 		if err := vehicle.GetVehicleStatus(ctx); err != nil {
@@ -544,8 +567,15 @@ func (c *Client) getContactMethods(ctx context.Context) error {
 	return nil
 }
 
-// RemoteUnlock unlocks the vehicle remotely.
+// RemoteUnlock unlocks all doors of the vehicle remotely.
 func (c *Client) RemoteUnlock(ctx context.Context, vin string) error {
+	return c.RemoteUnlockDoors(ctx, vin, UnlockAllDoors)
+}
+
+// RemoteUnlockDoors unlocks the selected doors of the vehicle remotely. The
+// door target is gated on the vehicle's feature codes the same way as
+// Vehicle.UnlockDoors.
+func (c *Client) RemoteUnlockDoors(ctx context.Context, vin string, door UnlockDoor) error {
 	if !c.hasVin(vin) {
 		return errors.New("VIN not in list")
 	}
@@ -562,8 +592,39 @@ func (c *Client) RemoteUnlock(ctx context.Context, vin string) error {
 	if !slices.Contains(vData.SubscriptionFeatures, FEATURE_REMOTE) {
 		return errors.New(appErrors["SUBSCRIPTION_REQUIRED"])
 	}
-	reqURL := MOBILE_API_VERSION + apiURLs["API_UNLOCK"]
-	params := map[string]string{"vin": vin}
+	if err := checkUnlockDoor(door, vData.Features); err != nil {
+		return err
+	}
+	reqURL := MOBILE_API_VERSION + urlToGen(apiURLs["API_UNLOCK"], apiGenFromFeatures(vData.Features))
+	pin, err := c.remotePIN(ctx, vin)
+	if err != nil {
+		return err
+	}
+	if err := c.checkPIN(pin); err != nil {
+		return err
+	}
+	params := map[string]string{
+		"delay":    "0",
+		"vin":      vin,
+		"pin":      pin,
+		WHICH_DOOR: string(door)}
+	if c.gated() {
+		loc, err := time.LoadLocation(vData.TimeZone)
+		if err != nil {
+			loc = time.Local
+		}
+		req := newCommandRequest(vin, reqURL, params, loc)
+		req.Location = LatLng{Lat: vData.VehicleGeoPosition.Latitude, Lng: vData.VehicleGeoPosition.Longitude}
+		req.Stolen = vData.StolenVehicle
+		req.DryRun = c.dryRunLog(ctx) != nil
+		if _, err := c.authorize(ctx, req); err != nil {
+			return err
+		}
+	}
+	if l := c.dryRunLog(ctx); l != nil {
+		c.recordDryRun(l, vin, reqURL, "", params)
+		return nil
+	}
 	resp, err := c.execute(ctx, POST, reqURL, params, false)
 	if err != nil {
 		return fmt.Errorf("RemoteUnlock request failed: %w", err)
@@ -571,12 +632,19 @@ func (c *Client) RemoteUnlock(ctx context.Context, vin string) error {
 	if !resp.Success {
 		return errors.New("RemoteUnlock failed")
 	}
-	c.logger.Info("Vehicle unlocked successfully", "vin", vin)
+	c.logger.Info("Vehicle unlocked successfully", "vin", vin, "door", string(door))
 	return nil
 }
 
 // execute executes an HTTP request based on the method, URL, and parameters provided.
+// Requests carrying a PIN are refused locally during a PIN lockout.
 func (c *Client) execute(ctx context.Context, method string, url string, params map[string]string, j bool) (*Response, error) {
+	if pin, ok := params["pin"]; ok {
+		if err := c.checkPIN(pin); err != nil {
+			return nil, err
+		}
+		c.pinSent(pin)
+	}
 	return c.executeWithRetry(ctx, method, url, params, j, 3)
 }
 
@@ -671,6 +739,19 @@ func (c *Client) handleAPIError(r *Response) {
 	}
 }
 
+// errorDescription returns the description of an errorResponse, if r holds
+// one.
+func errorDescription(r *Response) string {
+	if r.DataName != "errorResponse" {
+		return ""
+	}
+	var er ErrorResponse
+	if err := json.Unmarshal(r.Data, &er); err != nil {
+		return ""
+	}
+	return er.ErrorDescription
+}
+
 // handleVehicleSetupError handles the VEHICLESETUPERROR case and returns response/error accordingly.
 func (c *Client) handleVehicleSetupError(r *Response, method, url string, duration time.Duration) (*Response, error) {
 	// With vehicle data: treat as success (user needs to complete setup but data is functional)
@@ -707,6 +788,14 @@ func (c *Client) executeOnce(ctx context.Context, method string, url string, par
 		versionedURL := c.applyAPIVersion(url)
 		req := c.httpClient.R().SetContext(ctx)
 		resp, err = c.sendRequest(req, method, versionedURL, params, j)
+		if err == nil && resp.StatusCode() == 404 && hasGenerationFallback(url) {
+			// A generation-specific path the backend doesn't serve; the
+			// vehicle falls back to an older generation's endpoint instead.
+			_ = resp.Body.Close()
+			c.metrics.RecordRequest(method, url, time.Since(start), false)
+			c.logger.Debug("endpoint returned 404", "url", versionedURL)
+			return nil, ErrEndpointNotFound
+		}
 		if err == nil && resp.StatusCode() == 404 {
 			prev := c.getAPIVersion()
 			if c.bumpAPIVersion() {
@@ -785,7 +874,7 @@ func (c *Client) executeOnce(ctx context.Context, method string, url string, par
 			// Map the wire code to a typed error (NegativeAckError, PINLockedError,
 			// retryable APIError, etc.) so callers can use errors.As/Is. The retry
 			// layer keys off APIError.Code/Retryable, both preserved by ParseAPIError.
-			parsedErr := ParseAPIError(r.ErrorCode)
+			parsedErr := c.parseServiceError(r.ErrorCode, errorDescription(&r))
 			if IsSessionError(parsedErr) {
 				// The cached session-validity window no longer holds.
 				c.lastValidated.Store(0)
@@ -1,6 +1,7 @@
 package mysubaru
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"io"
@@ -462,6 +463,7 @@ type endpointRoute struct {
 	Method   string // HTTP method (GET, POST)
 	Path     string // URL path (without MOBILE_API_VERSION prefix)
 	Response string // JSON response string
+	Status   int    // HTTP status code (200 when zero)
 }
 
 // mockEndpointRouter creates a mock HTTP handler that routes requests based on path and method.
@@ -475,12 +477,14 @@ func mockEndpointRouter(routes []endpointRoute) http.HandlerFunc {
 			// Also check for paths with api_gen replacement
 			g1Path := MOBILE_API_VERSION + strings.ReplaceAll(route.Path, "api_gen", "g1")
 			g2Path := MOBILE_API_VERSION + strings.ReplaceAll(route.Path, "api_gen", "g2")
+			// G3 vehicles request G3 paths for G2 endpoints too
+			g3Path := MOBILE_API_VERSION + genPath(route.Path, FEATURE_G3_TELEMATICS)
 
-			matchesPath := r.URL.Path == fullPath || r.URL.Path == g1Path || r.URL.Path == g2Path
+			matchesPath := r.URL.Path == fullPath || r.URL.Path == g1Path || r.URL.Path == g2Path || r.URL.Path == g3Path
 			matchesMethod := route.Method == "" || r.Method == route.Method
 
 			if matchesPath && matchesMethod {
-				w.WriteHeader(http.StatusOK)
+				w.WriteHeader(cmp.Or(route.Status, http.StatusOK))
 				fmt.Fprint(w, route.Response)
 				return
 			}
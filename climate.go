@@ -0,0 +1,299 @@
+package mysubaru
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// TemperatureUnit is the unit a climate temperature is expressed in.
+type TemperatureUnit int
+
+const (
+	Fahrenheit TemperatureUnit = iota
+	Celsius
+)
+
+// Valid values for the remote start climate settings.
+var (
+	climateRunTimes     = []int{0, 1, 5, 10}
+	climateAirModes     = []string{MODE_DEFROST, MODE_FEET_DEFROST, MODE_FACE, MODE_FEET, MODE_SPLIT, MODE_AUTO}
+	climateFanSpeeds    = []string{FAN_SPEED_LOW, FAN_SPEED_MED, FAN_SPEED_HI, FAN_SPEED_AUTO}
+	climateSeatSettings = []string{HEAT_SEAT_OFF, HEAT_SEAT_LOW, HEAT_SEAT_MED, HEAT_SEAT_HI, COOL_SEAT_LOW, COOL_SEAT_MED, COOL_SEAT_HI}
+	climateCirculations = []string{RECIRCULATE_OFF, RECIRCULATE_ON, RECIRCULATE_AUTO}
+	climateBooleans     = []string{"true", "false"}
+	climateStartConfigs = []string{START_CONFIG_DEFAULT_RES, START_CONFIG_DEFAULT_EV}
+)
+
+// ClimateProfileBuilder assembles a ClimateProfile and validates it before it
+// reaches EngineStartWithProfile or SaveClimateUserPresets.
+//
+//	cp, err := mysubaru.NewClimateProfileBuilder("Winter").
+//		ForVehicle(vehicle).
+//		Temperature(21, mysubaru.Celsius).
+//		AirMode(mysubaru.MODE_FEET_DEFROST).
+//		FanSpeed(mysubaru.FAN_SPEED_HI).
+//		Build()
+type ClimateProfileBuilder struct {
+	cp ClimateProfile
+}
+
+// NewClimateProfileBuilder returns a builder for a user preset named name,
+// pre-filled with the package defaults.
+func NewClimateProfileBuilder(name string) *ClimateProfileBuilder {
+	temp, _ := strconv.Atoi(DefaultClimateTemp)
+	runTime, _ := strconv.Atoi(DefaultRunTime)
+	return &ClimateProfileBuilder{cp: ClimateProfile{
+		Name:                      name,
+		PresetType:                "userPreset",
+		RunTimeMinutes:            runTime,
+		ClimateZoneFrontTemp:      temp,
+		ClimateZoneFrontAirMode:   DefaultAirMode,
+		ClimateZoneFrontAirVolume: DefaultFanSpeed,
+		HeatedSeatFrontLeft:       HEAT_SEAT_OFF,
+		HeatedSeatFrontRight:      HEAT_SEAT_OFF,
+		HeatedRearWindowActive:    REAR_DEFROST_OFF,
+		OuterAirCirculation:       DefaultCirculation,
+		AirConditionOn:            REAR_AC_OFF,
+		CanEdit:                   "true",
+		Disabled:                  "false",
+	}}
+}
+
+// ForVehicle sets the vehicle type and the matching start configuration
+// (climate only for PHEVs, engine for gas vehicles).
+func (b *ClimateProfileBuilder) ForVehicle(v *Vehicle) *ClimateProfileBuilder {
+	if v.IsEV() {
+		b.cp.VehicleType = "phev"
+		b.cp.StartConfiguration = START_CONFIG_DEFAULT_EV
+	} else {
+		b.cp.VehicleType = "gas"
+		b.cp.StartConfiguration = START_CONFIG_DEFAULT_RES
+	}
+	return b
+}
+
+// Temperature sets the front zone temperature in the given unit. Fahrenheit is
+// sent as climateZoneFrontTemp, Celsius as climateZoneFrontTempCelsius.
+func (b *ClimateProfileBuilder) Temperature(value int, unit TemperatureUnit) *ClimateProfileBuilder {
+	if unit == Celsius {
+		b.cp.ClimateZoneFrontTemp = 0
+		b.cp.ClimateZoneFrontTempC = value
+	} else {
+		b.cp.ClimateZoneFrontTemp = value
+		b.cp.ClimateZoneFrontTempC = 0
+	}
+	return b
+}
+
+// AirMode sets the front zone air mode (MODE_* constants).
+func (b *ClimateProfileBuilder) AirMode(mode string) *ClimateProfileBuilder {
+	b.cp.ClimateZoneFrontAirMode = mode
+	return b
+}
+
+// FanSpeed sets the front zone fan speed (FAN_SPEED_* constants).
+func (b *ClimateProfileBuilder) FanSpeed(speed string) *ClimateProfileBuilder {
+	b.cp.ClimateZoneFrontAirVolume = speed
+	return b
+}
+
+// SeatHeat sets the front seat heating/cooling levels (HEAT_SEAT_* and COOL_SEAT_* constants).
+func (b *ClimateProfileBuilder) SeatHeat(left, right string) *ClimateProfileBuilder {
+	b.cp.HeatedSeatFrontLeft = left
+	b.cp.HeatedSeatFrontRight = right
+	return b
+}
+
+// RearDefrost turns the heated rear window on or off.
+func (b *ClimateProfileBuilder) RearDefrost(on bool) *ClimateProfileBuilder {
+	b.cp.HeatedRearWindowActive = strconv.FormatBool(on)
+	return b
+}
+
+// Circulation sets the air circulation (RECIRCULATE_* constants).
+func (b *ClimateProfileBuilder) Circulation(circulation string) *ClimateProfileBuilder {
+	b.cp.OuterAirCirculation = circulation
+	return b
+}
+
+// AirConditioning turns the air conditioning on or off.
+func (b *ClimateProfileBuilder) AirConditioning(on bool) *ClimateProfileBuilder {
+	b.cp.AirConditionOn = strconv.FormatBool(on)
+	return b
+}
+
+// RunTime sets the remote start run time in minutes (0, 1, 5 or 10).
+func (b *ClimateProfileBuilder) RunTime(minutes int) *ClimateProfileBuilder {
+	b.cp.RunTimeMinutes = minutes
+	return b
+}
+
+// StartConfiguration sets the start configuration (START_CONFIG_DEFAULT_* constants).
+func (b *ClimateProfileBuilder) StartConfiguration(config string) *ClimateProfileBuilder {
+	b.cp.StartConfiguration = config
+	return b
+}
+
+// Build validates the profile and returns it.
+func (b *ClimateProfileBuilder) Build() (ClimateProfile, error) {
+	if err := b.cp.Validate(); err != nil {
+		return ClimateProfile{}, err
+	}
+	return b.cp, nil
+}
+
+// Validate checks the profile's settings against the supported values. Empty
+// (zero) settings are treated as unset and skipped. When VehicleType is known,
+// the start configuration must match it: a climate-only start is rejected for
+// gas vehicles and an engine start for PHEVs. All problems are returned joined.
+func (cp ClimateProfile) Validate() error {
+	var errs []error
+
+	switch {
+	case cp.ClimateZoneFrontTemp != 0 && cp.ClimateZoneFrontTempC != 0:
+		errs = append(errs, errors.New("temperature must be set in either Fahrenheit or Celsius, not both"))
+	case cp.ClimateZoneFrontTemp != 0 && (cp.ClimateZoneFrontTemp < TEMP_F_MIN || cp.ClimateZoneFrontTemp > TEMP_F_MAX):
+		errs = append(errs, fmt.Errorf("temperature must be between %d and %d °F, got %d", TEMP_F_MIN, TEMP_F_MAX, cp.ClimateZoneFrontTemp))
+	case cp.ClimateZoneFrontTempC != 0 && (cp.ClimateZoneFrontTempC < TEMP_C_MIN || cp.ClimateZoneFrontTempC > TEMP_C_MAX):
+		errs = append(errs, fmt.Errorf("temperature must be between %d and %d °C, got %d", TEMP_C_MIN, TEMP_C_MAX, cp.ClimateZoneFrontTempC))
+	}
+	if !slices.Contains(climateRunTimes, cp.RunTimeMinutes) {
+		errs = append(errs, fmt.Errorf("run time must be one of %v minutes, got %d", climateRunTimes, cp.RunTimeMinutes))
+	}
+
+	checks := []struct {
+		field, value string
+		valid        []string
+	}{
+		{MODE, cp.ClimateZoneFrontAirMode, climateAirModes},
+		{FAN_SPEED, cp.ClimateZoneFrontAirVolume, climateFanSpeeds},
+		{HEAT_SEAT_LEFT, cp.HeatedSeatFrontLeft, climateSeatSettings},
+		{HEAT_SEAT_RIGHT, cp.HeatedSeatFrontRight, climateSeatSettings},
+		{REAR_DEFROST, cp.HeatedRearWindowActive, climateBooleans},
+		{RECIRCULATE, cp.OuterAirCirculation, climateCirculations},
+		{REAR_AC, cp.AirConditionOn, climateBooleans},
+		{START_CONFIG, cp.StartConfiguration, climateStartConfigs},
+	}
+	for _, c := range checks {
+		if c.value != "" && !containsFold(c.valid, c.value) {
+			errs = append(errs, fmt.Errorf("%s must be one of %v, got %q", c.field, c.valid, c.value))
+		}
+	}
+
+	switch {
+	case strings.EqualFold(cp.VehicleType, "gas") && strings.EqualFold(cp.StartConfiguration, START_CONFIG_DEFAULT_EV):
+		errs = append(errs, errors.New("climate-only start configuration requires a PHEV, vehicle type is gas"))
+	case strings.EqualFold(cp.VehicleType, "phev") && strings.EqualFold(cp.StartConfiguration, START_CONFIG_DEFAULT_RES):
+		errs = append(errs, errors.New("engine start configuration is not supported on a PHEV, use climate-only start"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateClimateProfile validates cp for this vehicle, taking the vehicle type
+// from the vehicle when the profile doesn't carry one.
+func (v *Vehicle) validateClimateProfile(cp ClimateProfile) error {
+	if cp.VehicleType == "" {
+		cp.VehicleType = "gas"
+		if v.IsEV() {
+			cp.VehicleType = "phev"
+		}
+	}
+	if err := cp.Validate(); err != nil {
+		return fmt.Errorf("invalid climate profile %q: %w", cp.Name, err)
+	}
+	return nil
+}
+
+// containsFold reports whether values contains s, ignoring case. The API
+// returns some settings in lower case (e.g. "feet_window", "high_heat").
+func containsFold(values []string, s string) bool {
+	return slices.ContainsFunc(values, func(v string) bool { return strings.EqualFold(v, s) })
+}
+
+// ClimateProfileAuto is the profile name that makes EngineStartWithProfile
+// choose a climate profile from the outside temperature.
+const ClimateProfileAuto = "auto"
+
+// ClimateBand selects Profile (a ClimateProfiles key) when the outside
+// temperature is in [Min, Max), expressed in Unit. Use math.Inf for an open end.
+type ClimateBand struct {
+	Min, Max float64
+	Unit     TemperatureUnit
+	Profile  string
+}
+
+// celsius returns the band bounds in °C.
+func (b ClimateBand) celsius() (float64, float64) {
+	if b.Unit == Celsius {
+		return b.Min, b.Max
+	}
+	return fahrenheitToCelsius(b.Min), fahrenheitToCelsius(b.Max)
+}
+
+// SetClimateBands sets the temperature bands used by ClimateProfileAuto. Bands
+// must not overlap; temperatures outside every band use the default settings.
+//
+//	vehicle.SetClimateBands([]mysubaru.ClimateBand{
+//		{Min: math.Inf(-1), Max: 5, Unit: mysubaru.Celsius, Profile: "subaru_preset_full_heat"},
+//		{Min: 25, Max: math.Inf(1), Unit: mysubaru.Celsius, Profile: "subaru_preset_full_cool"},
+//	})
+func (v *Vehicle) SetClimateBands(bands []ClimateBand) error {
+	sorted := slices.Clone(bands)
+	for _, b := range sorted {
+		if lo, hi := b.celsius(); !(lo < hi) {
+			return fmt.Errorf("climate band for %q must have Min below Max, got %v-%v", b.Profile, b.Min, b.Max)
+		}
+		if b.Profile == "" {
+			return errors.New("climate band profile cannot be empty")
+		}
+	}
+	slices.SortFunc(sorted, func(a, b ClimateBand) int {
+		al, _ := a.celsius()
+		bl, _ := b.celsius()
+		return cmp.Compare(al, bl)
+	})
+	for i := 1; i < len(sorted); i++ {
+		_, prevHi := sorted[i-1].celsius()
+		if lo, _ := sorted[i].celsius(); lo < prevHi {
+			return fmt.Errorf("climate bands for %q and %q overlap", sorted[i-1].Profile, sorted[i].Profile)
+		}
+	}
+
+	v.mu.Lock()
+	v.climateBands = sorted
+	v.mu.Unlock()
+	return nil
+}
+
+// autoClimateProfile returns the profile for the current outside temperature,
+// or "" (default settings) when the reading is missing or invalid, no band
+// matches, or the band's profile isn't available on the vehicle.
+func (v *Vehicle) autoClimateProfile() string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if !v.OutsideTemp.Valid {
+		v.client.logger.Debug("outside temperature unavailable, using default climate settings")
+		return ""
+	}
+	temp := v.OutsideTemp.Celsius
+	for _, b := range v.climateBands {
+		if lo, hi := b.celsius(); temp >= lo && temp < hi {
+			if _, ok := v.ClimateProfiles[b.Profile]; !ok {
+				v.client.logger.Warn("climate band profile not found, using default climate settings", "profile", b.Profile)
+				return ""
+			}
+			v.client.logger.Debug("selected climate profile from outside temperature", "profile", b.Profile, "celsius", temp)
+			return b.Profile
+		}
+	}
+	return ""
+}
+
+func celsiusToFahrenheit(c float64) float64 { return c*9/5 + 32 }
+
+func fahrenheitToCelsius(f float64) float64 { return (f - 32) * 5 / 9 }
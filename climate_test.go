@@ -0,0 +1,207 @@
+package mysubaru
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestClimateProfileBuilder_Celsius verifies a Celsius temperature is carried
+// in climateZoneFrontTempCelsius and replaces the Fahrenheit parameter.
+func TestClimateProfileBuilder_Celsius(t *testing.T) {
+	gas := &Vehicle{Features: []string{"g2"}}
+
+	cp, err := NewClimateProfileBuilder("Winter").
+		ForVehicle(gas).
+		Temperature(21, Celsius).
+		AirMode(MODE_FEET_DEFROST).
+		FanSpeed(FAN_SPEED_HI).
+		SeatHeat(HEAT_SEAT_HI, HEAT_SEAT_MED).
+		RearDefrost(true).
+		Build()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cp.ClimateZoneFrontTempC != 21 || cp.ClimateZoneFrontTemp != 0 {
+		t.Errorf("expected 21 °C only, got F=%d C=%d", cp.ClimateZoneFrontTemp, cp.ClimateZoneFrontTempC)
+	}
+	if cp.StartConfiguration != START_CONFIG_DEFAULT_RES {
+		t.Errorf("expected engine start configuration for gas vehicle, got %s", cp.StartConfiguration)
+	}
+
+	params := map[string]string{TEMP_F: DefaultClimateTemp}
+	applyClimateProfile(params, cp)
+	if _, ok := params[TEMP_F]; ok {
+		t.Errorf("expected %s to be removed, got %v", TEMP_F, params)
+	}
+	if params[TEMP_C] != "21" {
+		t.Errorf("expected %s=21, got %q", TEMP_C, params[TEMP_C])
+	}
+}
+
+// TestClimateProfileValidate covers the rejected settings and combinations.
+func TestClimateProfileValidate(t *testing.T) {
+	gas := &Vehicle{Features: []string{"g2"}}
+	phev := &Vehicle{Features: []string{"g2", FEATURE_PHEV}}
+
+	tests := []struct {
+		name    string
+		b       *ClimateProfileBuilder
+		wantErr string
+	}{
+		{"fahrenheit too low", NewClimateProfileBuilder("x").Temperature(59, Fahrenheit), "between 60 and 85"},
+		{"celsius too high", NewClimateProfileBuilder("x").Temperature(31, Celsius), "between 15 and 30"},
+		{"bad air mode", NewClimateProfileBuilder("x").AirMode("CEILING"), MODE},
+		{"bad fan speed", NewClimateProfileBuilder("x").FanSpeed("5"), FAN_SPEED},
+		{"bad seat", NewClimateProfileBuilder("x").SeatHeat("WARM", HEAT_SEAT_OFF), HEAT_SEAT_LEFT},
+		{"bad circulation", NewClimateProfileBuilder("x").Circulation("window"), RECIRCULATE},
+		{"bad run time", NewClimateProfileBuilder("x").RunTime(7), "run time"},
+		{"climate only on gas", NewClimateProfileBuilder("x").ForVehicle(gas).StartConfiguration(START_CONFIG_DEFAULT_EV), "requires a PHEV"},
+		{"engine start on phev", NewClimateProfileBuilder("x").ForVehicle(phev).StartConfiguration(START_CONFIG_DEFAULT_RES), "not supported on a PHEV"},
+		{"valid phev", NewClimateProfileBuilder("x").ForVehicle(phev).Temperature(72, Fahrenheit), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.b.Build()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	// Values from the API come back in lower case and must still validate.
+	api := ClimateProfile{ClimateZoneFrontAirMode: "feet_window", HeatedSeatFrontLeft: "high_heat", OuterAirCirculation: "auto", RunTimeMinutes: 10}
+	if err := api.Validate(); err != nil {
+		t.Errorf("expected lower-case API values to validate, got %v", err)
+	}
+}
+
+// TestSaveClimateUserPresets_RejectsInvalid verifies invalid presets are
+// rejected before any request is made.
+func TestSaveClimateUserPresets_RejectsInvalid(t *testing.T) {
+	v := &Vehicle{
+		Features:             []string{"g2"},
+		SubscriptionFeatures: []string{FEATURE_REMOTE},
+		client:               &Client{logger: slog.New(slog.NewTextHandler(io.Discard, nil))},
+	}
+
+	preset := ClimateProfile{Name: "EV only", RunTimeMinutes: 10, StartConfiguration: START_CONFIG_DEFAULT_EV}
+	err := v.SaveClimateUserPresets(context.Background(), []ClimateProfile{preset})
+	if err == nil || !strings.Contains(err.Error(), "requires a PHEV") {
+		t.Fatalf("expected climate-only start to be rejected for gas vehicle, got %v", err)
+	}
+}
+
+// TestSaveClimateUserPresets verifies presets are saved to the G2 endpoint,
+// which G3 vehicles share, and that a failed save is reported.
+func TestSaveClimateUserPresets(t *testing.T) {
+	g3Save := genPath(apiURLs["API_G2_SAVE_RES_SETTINGS"], FEATURE_G3_TELEMATICS)
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr bool
+	}{
+		{"saved", http.StatusOK, `{"success":true,"errorCode":null,"dataName":null,"data":null}`, false},
+		{"server error", http.StatusInternalServerError, `{"success":false,"errorCode":null,"dataName":null,"data":null}`, true},
+		{"rejected", http.StatusOK, `{"success":false,"errorCode":"InvalidToken","dataName":null,"data":null}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, _ := setupCountingVehicle(t, false,
+				endpointRoute{Method: http.MethodPost, Path: g3Save, Status: http.StatusNotFound, Response: `{"success":false,"errorCode":"404-soa-unableToParseResponseBody","dataName":null,"data":null}`},
+				endpointRoute{Method: http.MethodPost, Path: apiURLs["API_G2_SAVE_RES_SETTINGS"], Status: tt.status, Response: tt.body},
+			)
+			err := v.SaveClimateUserPresets(context.Background(), []ClimateProfile{{Name: "Morning", RunTimeMinutes: 10}})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// TestOutsideTemp verifies outside temperature parsing, including the bad
+// sensor value and readings sent as strings or numbers.
+func TestOutsideTemp(t *testing.T) {
+	for _, body := range []string{`{"outsideTemp":"22.5"}`, `{"outsideTemp":22.5}`} {
+		var vs VehicleStatus
+		if err := json.Unmarshal([]byte(body), &vs); err != nil {
+			t.Fatalf("expected no error for %s, got %v", body, err)
+		}
+		v := &Vehicle{}
+		v.updateVehicleFromStatus(&vs)
+		if !v.OutsideTemp.Valid || v.OutsideTemp.Celsius != 22.5 || v.OutsideTemp.Fahrenheit != 72.5 {
+			t.Errorf("%s: expected valid 22.5 °C / 72.5 °F, got %+v", body, v.OutsideTemp)
+		}
+	}
+
+	v := &Vehicle{}
+	v.updateOutsideTemp("10")
+	v.updateOutsideTemp("")
+	if !v.OutsideTemp.Valid || v.OutsideTemp.Celsius != 10 {
+		t.Errorf("expected missing reading to keep the previous one, got %+v", v.OutsideTemp)
+	}
+	v.updateOutsideTemp(BAD_EXTERNAL_TEMP)
+	if v.OutsideTemp.Valid {
+		t.Errorf("expected %s to mark the reading invalid", BAD_EXTERNAL_TEMP)
+	}
+}
+
+// TestAutoClimateProfile verifies profile selection by temperature band and
+// the fallback to defaults.
+func TestAutoClimateProfile(t *testing.T) {
+	v := &Vehicle{
+		ClimateProfiles: map[string]ClimateProfile{
+			"subaru_preset_full_heat": {Name: "Full Heat"},
+			"subaru_preset_full_cool": {Name: "Full Cool"},
+		},
+		client: &Client{logger: slog.New(slog.NewTextHandler(io.Discard, nil))},
+	}
+	err := v.SetClimateBands([]ClimateBand{
+		{Min: 77, Max: math.Inf(1), Unit: Fahrenheit, Profile: "subaru_preset_full_cool"},
+		{Min: math.Inf(-1), Max: 5, Unit: Celsius, Profile: "subaru_preset_full_heat"},
+		{Min: 5, Max: 10, Unit: Celsius, Profile: "user_preset_missing"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		reading json.Number
+		want    string
+	}{
+		{"-12", "subaru_preset_full_heat"},
+		{"30", "subaru_preset_full_cool"},
+		{"18", ""},              // no band
+		{"7", ""},               // band profile not on the vehicle
+		{BAD_EXTERNAL_TEMP, ""}, // invalid reading
+	}
+	for _, tt := range tests {
+		v.updateOutsideTemp(tt.reading)
+		if got := v.autoClimateProfile(); got != tt.want {
+			t.Errorf("reading %s: expected %q, got %q", tt.reading, tt.want, got)
+		}
+	}
+
+	overlap := []ClimateBand{
+		{Min: 0, Max: 10, Unit: Celsius, Profile: "a"},
+		{Min: 45, Max: 60, Unit: Fahrenheit, Profile: "b"},
+	}
+	if err := v.SetClimateBands(overlap); err == nil {
+		t.Error("expected overlapping bands to be rejected")
+	}
+	if err := v.SetClimateBands([]ClimateBand{{Min: 10, Max: 0, Profile: "a"}}); err == nil {
+		t.Error("expected empty band to be rejected")
+	}
+}
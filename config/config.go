@@ -30,14 +30,18 @@ const (
 type Config struct {
 	MySubaru MySubaru
 	TimeZone string
-	Logger   *slog.Logger
-	Metrics  MetricsRecorder
+	// Units is the unit system quantities are displayed in: "imperial" or
+	// "metric". Empty selects the system customary in the region.
+	Units   string
+	Logger  *slog.Logger
+	Metrics MetricsRecorder
 }
 
 // config defines the structure of configuration data to be parsed from a config source.
 type config struct {
 	MySubaru MySubaru `json:"mysubaru" yaml:"mysubaru"`
 	TimeZone string   `json:"timezone" yaml:"timezone"`
+	Units    string   `json:"units,omitempty" yaml:"units,omitempty"`
 	Logging  *Logging `json:"logging" yaml:"logging"`
 }
 
@@ -49,6 +53,9 @@ type MySubaru struct {
 	// BaseURL overrides the regional mobile-API host (e.g. to target a QA
 	// environment or a local mock). Leave empty to use the regional default.
 	BaseURL string `json:"base_url,omitempty" yaml:"base_url,omitempty"`
+	// DryRun simulates remote commands: they are validated and built but
+	// never sent, and are recorded in Client.DryRunLog instead.
+	DryRun bool `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
 }
 
 // Credentials .
@@ -109,6 +116,7 @@ func FromBytes(b []byte) (*Config, error) {
 
 	o.MySubaru = c.MySubaru
 	o.TimeZone = c.TimeZone
+	o.Units = c.Units
 	if c.Logging != nil {
 		o.Logger = c.Logging.ToLogger()
 	} else {
@@ -446,6 +446,9 @@ const (
 	HEAT_SEAT_MED   = "MEDIUM_HEAT"
 	HEAT_SEAT_LOW   = "LOW_HEAT"
 	HEAT_SEAT_OFF   = "OFF"
+	COOL_SEAT_HI    = "HIGH_COOL"
+	COOL_SEAT_MED   = "MEDIUM_COOL"
+	COOL_SEAT_LOW   = "LOW_COOL"
 
 	// Rear defrost options
 	REAR_DEFROST     = "heatedRearWindowActive"
@@ -460,9 +463,10 @@ const (
 	FAN_SPEED_AUTO = "AUTO"
 
 	// Air circulation options
-	RECIRCULATE     = "outerAirCirculation"
-	RECIRCULATE_OFF = "outsideAir"
-	RECIRCULATE_ON  = "recirculation"
+	RECIRCULATE      = "outerAirCirculation"
+	RECIRCULATE_OFF  = "outsideAir"
+	RECIRCULATE_ON   = "recirculation"
+	RECIRCULATE_AUTO = "auto"
 
 	// Rear AC options
 	REAR_AC     = "airConditionOn"
@@ -475,9 +479,10 @@ const (
 	START_CONFIG_DEFAULT_RES = "START_ENGINE_ALLOW_KEY_IN_IGNITION"
 
 	// Door unlock options
-	WHICH_DOOR   = "unlockDoorType"
-	ALL_DOORS    = "ALL_DOORS_CMD"
-	DRIVERS_DOOR = "FRONT_LEFT_DOOR_CMD"
+	WHICH_DOOR    = "unlockDoorType"
+	ALL_DOORS     = "ALL_DOORS_CMD"
+	DRIVERS_DOOR  = "FRONT_LEFT_DOOR_CMD"
+	TAILGATE_DOOR = "TAILGATE_DOOR_CMD"
 
 	// Location data constants
 	HEADING       = "heading"
@@ -614,10 +619,15 @@ const (
 	FEATURE_G1_TELEMATICS = "g1"
 	FEATURE_G2_TELEMATICS = "g2"
 	FEATURE_G3_TELEMATICS = "g3"
+	FEATURE_TAILGATE      = "RTGU"
 	FEATURE_REMOTE        = "REMOTE"
 	FEATURE_SAFETY        = "SAFETY"
 	FEATURE_ACTIVE        = "ACTIVE"
 
+	// Tire placard feature prefixes, followed by the pressure (TIF_35, TIR_33)
+	FEATURE_TIRE_FRONT_PLACARD = "TIF_"
+	FEATURE_TIRE_REAR_PLACARD  = "TIR_"
+
 	// Update intervals (in seconds)
 	DEFAULT_UPDATE_INTERVAL = 7200
 	DEFAULT_FETCH_INTERVAL  = 300
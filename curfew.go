@@ -0,0 +1,358 @@
+package mysubaru
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// curfewDayNames are the day codes the curfew endpoints use, by time.Weekday.
+var curfewDayNames = [...]string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// CurfewWindow is a curfew period starting at Start on each of Days and
+// ending at End. An End at or before Start ends on the following day, so
+// 22:00–06:00 on Friday runs until Saturday 06:00.
+type CurfewWindow struct {
+	Start string         // HH:MM format
+	End   string         // HH:MM format
+	Days  []time.Weekday // days the window starts on
+}
+
+// Validate checks the window's times and days.
+func (w CurfewWindow) Validate() error {
+	if err := ValidateTimeRange(w.Start, w.End); err != nil {
+		return fmt.Errorf("invalid time range: %w", err)
+	}
+	if clockMinutes(w.Start) == clockMinutes(w.End) {
+		return errors.New("curfew start and end time must differ")
+	}
+	if len(w.Days) == 0 {
+		return errors.New("curfew window needs at least one day")
+	}
+	days := make([]int, len(w.Days))
+	for i, d := range w.Days {
+		days[i] = int(d)
+	}
+	if err := ValidateDaysOfWeek(days); err != nil {
+		return fmt.Errorf("invalid days of week: %w", err)
+	}
+	return nil
+}
+
+// Overnight reports whether the window crosses midnight.
+func (w CurfewWindow) Overnight() bool {
+	return clockMinutes(w.End) <= clockMinutes(w.Start)
+}
+
+// activeAt reports whether the window covers the wall-clock time t.
+func (w CurfewWindow) activeAt(t time.Time) bool {
+	now := t.Hour()*60 + t.Minute()
+	start, end := clockMinutes(w.Start), clockMinutes(w.End)
+	if !w.Overnight() {
+		return slices.Contains(w.Days, t.Weekday()) && now >= start && now < end
+	}
+	// Overnight: the evening part belongs to today, the morning part to the
+	// window that started yesterday.
+	yesterday := (t.Weekday() + 6) % 7
+	return slices.Contains(w.Days, t.Weekday()) && now >= start ||
+		slices.Contains(w.Days, yesterday) && now < end
+}
+
+// MarshalJSON writes the window as startTime/endTime/days.
+func (w CurfewWindow) MarshalJSON() ([]byte, error) {
+	days := make([]string, 0, len(w.Days))
+	for _, d := range w.Days {
+		if d >= time.Sunday && d <= time.Saturday {
+			days = append(days, curfewDayNames[d])
+		}
+	}
+	return json.Marshal(struct {
+		StartTime string   `json:"startTime"`
+		EndTime   string   `json:"endTime"`
+		Days      []string `json:"days"`
+	}{w.Start, w.End, days})
+}
+
+// UnmarshalJSON reads startTime/endTime and days given as day codes (SUN,
+// MON, ...) or as numbers (0=Sunday) in days or daysOfWeek.
+func (w *CurfewWindow) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		StartTime  string            `json:"startTime"`
+		EndTime    string            `json:"endTime"`
+		Days       []json.RawMessage `json:"days"`
+		DaysOfWeek []json.RawMessage `json:"daysOfWeek"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*w = CurfewWindow{Start: aux.StartTime, End: aux.EndTime}
+	for _, raw := range append(aux.Days, aux.DaysOfWeek...) {
+		d, err := parseCurfewDay(raw)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(w.Days, d) {
+			w.Days = append(w.Days, d)
+		}
+	}
+	return nil
+}
+
+// parseCurfewDay reads a day code ("MON", "Monday") or number (1).
+func parseCurfewDay(raw json.RawMessage) (time.Weekday, error) {
+	var n int
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return time.Weekday(n), nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return 0, err
+	}
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if n, err := strconv.Atoi(s); err == nil {
+		return time.Weekday(n), nil
+	}
+	for d, name := range curfewDayNames {
+		if len(s) >= 3 && strings.HasPrefix(s, name) {
+			return time.Weekday(d), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown curfew day %q", s)
+}
+
+// clockMinutes returns the minutes since midnight of an HH:MM time, or -1.
+func clockMinutes(hhmm string) int {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return -1
+	}
+	return t.Hour()*60 + t.Minute()
+}
+
+// =============================================================================
+// Curfew Alerts
+// =============================================================================
+
+// CurfewSettings represents a curfew (time fence) alert configuration. Each
+// window can cover different days, giving per-day schedules.
+type CurfewSettings struct {
+	Enabled bool
+	Name    string
+	Windows []CurfewWindow
+}
+
+// curfewJSON is the wire shape of CurfewSettings: a single window inline, as
+// the fetch endpoint returns it, or several windows in schedules.
+type curfewJSON struct {
+	Enabled   bool           `json:"enabled"`
+	Name      string         `json:"name,omitempty"`
+	Schedules []CurfewWindow `json:"schedules,omitempty"`
+}
+
+// MarshalJSON writes a single window inline and several in schedules.
+func (s CurfewSettings) MarshalJSON() ([]byte, error) {
+	head, err := json.Marshal(curfewJSON{Enabled: s.Enabled, Name: s.Name})
+	if err != nil {
+		return nil, err
+	}
+	if len(s.Windows) == 0 {
+		return head, nil
+	}
+	if len(s.Windows) > 1 {
+		return json.Marshal(curfewJSON{Enabled: s.Enabled, Name: s.Name, Schedules: s.Windows})
+	}
+	window, err := json.Marshal(s.Windows[0])
+	if err != nil {
+		return nil, err
+	}
+	// Merge {"enabled":..,"name":..} and {"startTime":..,...}.
+	return append(append(head[:len(head)-1], ','), window[1:]...), nil
+}
+
+// UnmarshalJSON reads the inline window and any windows in schedules.
+func (s *CurfewSettings) UnmarshalJSON(data []byte) error {
+	var aux curfewJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*s = CurfewSettings{Enabled: aux.Enabled, Name: aux.Name, Windows: aux.Schedules}
+	var inline CurfewWindow
+	if err := json.Unmarshal(data, &inline); err != nil {
+		return err
+	}
+	if inline.Start != "" || inline.End != "" {
+		s.Windows = append([]CurfewWindow{inline}, s.Windows...)
+	}
+	return nil
+}
+
+// Validate checks every window.
+func (s CurfewSettings) Validate() error {
+	if len(s.Windows) == 0 {
+		return errors.New("curfew needs at least one window")
+	}
+	for i, w := range s.Windows {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("curfew window %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// ActiveAt reports whether the curfew is enabled and one of its windows covers
+// t, read as wall-clock time in loc.
+func (s CurfewSettings) ActiveAt(t time.Time, loc *time.Location) bool {
+	if !s.Enabled {
+		return false
+	}
+	t = t.In(loc)
+	return slices.ContainsFunc(s.Windows, func(w CurfewWindow) bool { return w.activeAt(t) })
+}
+
+// params returns the request parameters of the save endpoint: the first
+// window inline and, when there are several, all windows JSON-encoded in
+// schedules.
+func (s CurfewSettings) params(vin string) (map[string]string, error) {
+	params := map[string]string{
+		"vin":     vin,
+		"enabled": strconv.FormatBool(s.Enabled),
+	}
+	if s.Name != "" {
+		params["name"] = s.Name
+	}
+	if len(s.Windows) == 0 {
+		return params, nil
+	}
+	w := s.Windows[0]
+	days := make([]string, 0, len(w.Days))
+	for _, d := range w.Days {
+		days = append(days, curfewDayNames[d])
+	}
+	params["startTime"] = w.Start
+	params["endTime"] = w.End
+	params["days"] = strings.Join(days, ",")
+	if len(s.Windows) > 1 {
+		schedules, err := json.Marshal(s.Windows)
+		if err != nil {
+			return nil, err
+		}
+		params["schedules"] = string(schedules)
+	}
+	return params, nil
+}
+
+// executeParams returns the request parameters of the execute endpoint, which
+// takes a single window with its days as numbers (0 for Sunday) in
+// daysOfWeek.
+func (s CurfewSettings) executeParams(vin string) (map[string]string, error) {
+	if len(s.Windows) != 1 {
+		return nil, errors.New("curfew can only be sent to the vehicle with a single window; use SaveCurfewSettings for several")
+	}
+	w := s.Windows[0]
+	days := make([]string, 0, len(w.Days))
+	for _, d := range w.Days {
+		days = append(days, strconv.Itoa(int(d)))
+	}
+	return map[string]string{
+		"vin":        vin,
+		"startTime":  w.Start,
+		"endTime":    w.End,
+		"daysOfWeek": strings.Join(days, ","),
+		"enabled":    strconv.FormatBool(s.Enabled),
+	}, nil
+}
+
+// InCurfew reports whether t falls within the curfew, interpreting the
+// curfew times in the vehicle's time zone.
+func (v *Vehicle) InCurfew(settings CurfewSettings, t time.Time) bool {
+	return settings.ActiveAt(t, v.Location())
+}
+
+// GetCurfewSettings retrieves the current curfew alert settings
+func (v *Vehicle) GetCurfewSettings(ctx context.Context) (*CurfewSettings, error) {
+	if err := v.checkSafetyFeature("curfew"); err != nil {
+		return nil, err
+	}
+	var settings CurfewSettings
+	if err := v.fetchInto(ctx, GET, "API_G2_CURFEW_FETCH", map[string]string{}, false, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveCurfewSettings validates and saves curfew alert settings
+func (v *Vehicle) SaveCurfewSettings(ctx context.Context, settings CurfewSettings) error {
+	if err := v.checkSafetyFeature("curfew"); err != nil {
+		return err
+	}
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	params, err := settings.params(v.Vin)
+	if err != nil {
+		return err
+	}
+	return v.fetchInto(ctx, POST, "API_G2_CURFEW_SAVE", params, true, nil)
+}
+
+// SetCurfew sets up a curfew for the vehicle and sends it to the vehicle
+// right away.
+func (v *Vehicle) SetCurfew(ctx context.Context, settings CurfewSettings) (chan string, error) {
+	if err := v.checkSafetyFeature("curfew"); err != nil {
+		return nil, err
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := settings.executeParams(v.Vin)
+	if err != nil {
+		return nil, err
+	}
+	params["delay"] = "0"
+	params["pin"] = pin
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityCurfew, "API_G2_CURFEW")
+	if err != nil {
+		return nil, err
+	}
+
+	return v.actuate(ctx, params, reqUrl, pollingUrl)
+}
+
+// ActivateCurfew activates the curfew alert on the vehicle
+func (v *Vehicle) ActivateCurfew(ctx context.Context) (chan string, error) {
+	return v.switchCurfew(ctx, "activate")
+}
+
+// DeactivateCurfew deactivates the curfew alert on the vehicle
+func (v *Vehicle) DeactivateCurfew(ctx context.Context) (chan string, error) {
+	return v.switchCurfew(ctx, "deactivate")
+}
+
+func (v *Vehicle) switchCurfew(ctx context.Context, action string) (chan string, error) {
+	if err := v.checkSafetyFeature("curfew"); err != nil {
+		return nil, err
+	}
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		"vin":    v.Vin,
+		"pin":    pin,
+		"action": action,
+	}
+	reqUrl := v.endpointURL("API_G2_CURFEW")
+	pollingUrl := v.endpointURL("API_G2_CURFEW_STATUS")
+
+	return v.actuate(ctx, params, reqUrl, pollingUrl)
+}
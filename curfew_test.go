@@ -0,0 +1,87 @@
+package mysubaru
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestCurfewSettings_RoundTrip reads the fetch shape, adds a second window and
+// checks both shapes survive a marshal/unmarshal cycle.
+func TestCurfewSettings_RoundTrip(t *testing.T) {
+	var s CurfewSettings
+	body := `{"enabled":true,"name":"Night Curfew","days":["SUN","MON","TUE","WED","THU"],"startTime":"22:00","endTime":"06:00"}`
+	if err := json.Unmarshal([]byte(body), &s); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(s.Windows) != 1 || len(s.Windows[0].Days) != 5 || s.Windows[0].Days[1] != time.Monday || !s.Windows[0].Overnight() {
+		t.Fatalf("unexpected settings: %+v", s)
+	}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	s.Windows = append(s.Windows, CurfewWindow{Start: "00:00", End: "07:00", Days: []time.Weekday{time.Saturday}})
+	for _, want := range []CurfewSettings{{Enabled: true, Name: "x", Windows: s.Windows[:1]}, s} {
+		data, err := json.Marshal(want)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		var got CurfewSettings
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if got.Enabled != want.Enabled || got.Name != want.Name || len(got.Windows) != len(want.Windows) {
+			t.Fatalf("expected %+v after round trip, got %+v", want, got)
+		}
+		for i := range want.Windows {
+			if got.Windows[i].Start != want.Windows[i].Start || len(got.Windows[i].Days) != len(want.Windows[i].Days) {
+				t.Errorf("window %d: expected %+v, got %+v", i, want.Windows[i], got.Windows[i])
+			}
+		}
+	}
+
+	params, err := s.params("VIN")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if params["days"] != "SUN,MON,TUE,WED,THU" || params["startTime"] != "22:00" || params["schedules"] == "" {
+		t.Errorf("unexpected params: %v", params)
+	}
+	if _, err := s.executeParams("VIN"); err == nil {
+		t.Error("expected several windows to be rejected by the execute endpoint")
+	}
+}
+
+// TestCurfewActiveAt checks overnight windows in the vehicle's time zone.
+func TestCurfewActiveAt(t *testing.T) {
+	s := CurfewSettings{Enabled: true, Windows: []CurfewWindow{
+		{Start: "22:00", End: "06:00", Days: []time.Weekday{time.Friday}},
+	}}
+	v := &Vehicle{TimeZone: "America/Denver"}
+	loc := v.Location()
+
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{time.Date(2026, 5, 1, 23, 0, 0, 0, loc), true},      // Friday night
+		{time.Date(2026, 5, 2, 5, 59, 0, 0, loc), true},      // Saturday morning, same window
+		{time.Date(2026, 5, 2, 6, 0, 0, 0, loc), false},      // window over
+		{time.Date(2026, 5, 2, 23, 0, 0, 0, loc), false},     // Saturday night not included
+		{time.Date(2026, 5, 1, 5, 0, 0, 0, loc), false},      // Friday morning belongs to Thursday
+		{time.Date(2026, 5, 2, 4, 30, 0, 0, time.UTC), true}, // 22:30 Friday in Denver
+	}
+	for _, tt := range tests {
+		if got := v.InCurfew(s, tt.at); got != tt.want {
+			t.Errorf("%v: expected %v, got %v", tt.at, tt.want, got)
+		}
+	}
+
+	for _, w := range []CurfewWindow{{Start: "22:00", End: "22:00"}, {Start: "7:00", End: "07:00"}} {
+		w.Days = []time.Weekday{time.Monday}
+		if err := w.Validate(); err == nil {
+			t.Errorf("expected zero-length window %s-%s to be rejected", w.Start, w.End)
+		}
+	}
+}
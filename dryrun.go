@@ -0,0 +1,149 @@
+package mysubaru
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Dry Run
+// =============================================================================
+
+// dryRunServiceRequestID is the service request ID of simulated commands.
+const dryRunServiceRequestID = "DRYRUN"
+
+// redactedParams lists request parameters whose values are hidden in dry-run
+// records and policy requests.
+var redactedParams = []string{"pin", "valetPin", "password"}
+
+// DryRunCommand is a remote command simulated in dry-run mode: the request
+// that would have been sent, with secrets redacted.
+type DryRunCommand struct {
+	Vin             string
+	Method          string
+	Endpoint        string            // command URL, with host and API version
+	PollingEndpoint string            // status URL the command would be polled on
+	Params          map[string]string // request parameters; PINs read "[REDACTED]"
+	Time            time.Time
+}
+
+// DryRunLog records the commands simulated in dry-run mode. It is safe for
+// concurrent use.
+type DryRunLog struct {
+	mu       sync.Mutex
+	commands []DryRunCommand
+}
+
+// Commands returns the simulated commands, oldest first.
+func (l *DryRunLog) Commands() []DryRunCommand {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return slices.Clone(l.commands)
+}
+
+// Last returns the most recently simulated command.
+func (l *DryRunLog) Last() (DryRunCommand, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.commands) == 0 {
+		return DryRunCommand{}, false
+	}
+	return l.commands[len(l.commands)-1], true
+}
+
+func (l *DryRunLog) record(cmd DryRunCommand) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.commands = append(l.commands, cmd)
+}
+
+type dryRunKey struct{}
+
+// WithDryRun returns a copy of ctx under which remote commands are simulated,
+// and the log they are recorded in. Validation, session checks and parameter
+// building run as usual, but nothing is posted to the remote service endpoint
+// and the command's channel receives "finished".
+func WithDryRun(ctx context.Context) (context.Context, *DryRunLog) {
+	l := &DryRunLog{}
+	return context.WithValue(ctx, dryRunKey{}, l), l
+}
+
+// DryRunLog returns the log of commands simulated by a client created with
+// MySubaru.DryRun set, or nil when the client sends commands.
+func (c *Client) DryRunLog() *DryRunLog {
+	return c.dryRun
+}
+
+// dryRunLog returns the log a command run under ctx is simulated into: the
+// one from WithDryRun, else the client's. It is nil for live commands.
+func (c *Client) dryRunLog(ctx context.Context) *DryRunLog {
+	if l, ok := ctx.Value(dryRunKey{}).(*DryRunLog); ok {
+		return l
+	}
+	return c.dryRun
+}
+
+// recordDryRun records a command to reqUrl in l instead of sending it.
+func (c *Client) recordDryRun(l *DryRunLog, vin, reqUrl, pollingUrl string, params map[string]string) {
+	cmd := DryRunCommand{
+		Vin:      vin,
+		Method:   POST,
+		Endpoint: c.baseURL + c.applyAPIVersion(reqUrl),
+		Params:   redact(params),
+		Time:     time.Now(),
+	}
+	if pollingUrl != "" {
+		cmd.PollingEndpoint = c.baseURL + c.applyAPIVersion(pollingUrl)
+	}
+	l.record(cmd)
+	c.logger.Info("dry run: remote command not sent", "vin", vin, "endpoint", cmd.Endpoint)
+}
+
+// simulate runs the preamble of a remote command and records it in l instead
+// of posting it, returning the finished service request it stands in for.
+func (v *Vehicle) simulate(ctx context.Context, l *DryRunLog, params map[string]string, reqUrl, pollingUrl string) (*ServiceRequest, error) {
+	if err := v.validateSubscriptionAndSession(ctx); err != nil {
+		return nil, err
+	}
+	v.ensureVehicleSelected(ctx)
+
+	// Record the endpoint execute would actually reach first.
+	urls := fallbackURLs(reqUrl)
+	reqUrl = urls[len(urls)-1]
+	for _, u := range urls {
+		if _, missing := v.missingEndpoints.Load(u); !missing {
+			reqUrl = u
+			break
+		}
+	}
+	v.client.recordDryRun(l, v.Vin, reqUrl, pollingUrl, params)
+	return &ServiceRequest{
+		ServiceRequestID:   dryRunServiceRequestID,
+		Vin:                v.Vin,
+		Success:            true,
+		RemoteServiceState: "finished",
+	}, nil
+}
+
+// simulated returns a closed channel holding the final state of a simulated
+// command.
+func simulated() chan string {
+	ch := make(chan string, 1)
+	ch <- "finished"
+	close(ch)
+	return ch
+}
+
+// redact returns a copy of params with the values of redactedParams hidden.
+func redact(params map[string]string) map[string]string {
+	p := maps.Clone(params)
+	for _, k := range redactedParams {
+		if _, ok := p[k]; ok {
+			p[k] = "[REDACTED]"
+		}
+	}
+	return p
+}
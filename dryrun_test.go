@@ -0,0 +1,150 @@
+package mysubaru
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// setupCountingVehicle starts a mock server answering routes before the
+// standard ones and counting remote service requests, and returns an
+// authenticated vehicle of a client with the given dry-run setting.
+func setupCountingVehicle(t *testing.T, dryRun bool, routes ...endpointRoute) (*Vehicle, *atomic.Int32) {
+	t.Helper()
+	ts := mockServerWithRoutes(t, append(routes, standardTestRoutes()...))
+	var sent atomic.Int32
+	router := ts.Config.Handler
+	ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/service/") {
+			sent.Add(1)
+		}
+		router.ServeHTTP(w, r)
+	})
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	cfg := mockConfig(t)
+	cfg.MySubaru.Credentials.PIN = "1234"
+	cfg.MySubaru.DryRun = dryRun
+	msc, err := New(cfg)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ok, _, err := msc.Authenticate(context.Background()); !ok || err != nil {
+		t.Fatalf("expected authentication to succeed, got ok=%v, err=%v", ok, err)
+	}
+	v, err := msc.GetVehicleByVin(context.Background(), "1HGCM82633A004352")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	v.SubscriptionFeatures = append(v.SubscriptionFeatures, FEATURE_SAFETY)
+	sent.Store(0)
+	return v, &sent
+}
+
+// TestDryRun verifies a dry-run client simulates remote commands, recording
+// the endpoint and redacted params without sending them.
+func TestDryRun(t *testing.T) {
+	v, sent := setupCountingVehicle(t, true)
+	ctx := context.Background()
+
+	commands := []struct {
+		name     string
+		endpoint string
+		run      func() (chan string, error)
+	}{
+		{"Lock", "/service/g3/lock/execute.json", func() (chan string, error) { return v.Lock(ctx) }},
+		{"Unlock", "/service/g3/unlock/execute.json", func() (chan string, error) { return v.Unlock(ctx) }},
+		{"EngineStartWithProfile", "/service/g3/engineStart/execute.json", func() (chan string, error) {
+			return v.EngineStartWithProfile(ctx, 10, 0, false, "")
+		}},
+		{"SendPOI", "/service/g3/sendPoi/execute.json", func() (chan string, error) {
+			return v.SendPOI(ctx, POI{Name: "Home", Latitude: 40.7, Longitude: -74})
+		}},
+		{"CreateGeoFence", "/service/g3/geoFence/execute.json", func() (chan string, error) {
+			return v.CreateGeoFence(ctx, GeoFence{Name: "Home", Latitude: 40.7, Longitude: -74, Radius: 500})
+		}},
+		{"SetSpeedFence", "/service/g3/speedFence/execute.json", func() (chan string, error) {
+			return v.SetSpeedFence(ctx, SpeedFenceSettings{Enabled: true, Limit: SpeedMPH(70)}, true)
+		}},
+	}
+	for _, c := range commands {
+		ch, err := c.run()
+		if err != nil {
+			t.Fatalf("%s: expected no error, got %v", c.name, err)
+		}
+		if state := <-ch; state != "finished" {
+			t.Errorf("%s: expected finished, got %q", c.name, state)
+		}
+		cmd, ok := v.client.DryRunLog().Last()
+		if !ok {
+			t.Fatalf("%s: expected a recorded command", c.name)
+		}
+		if !strings.HasSuffix(cmd.Endpoint, c.endpoint) {
+			t.Errorf("%s: expected endpoint ending in %s, got %s", c.name, c.endpoint, cmd.Endpoint)
+		}
+		if cmd.Params["pin"] != "[REDACTED]" || cmd.Params["vin"] != v.Vin {
+			t.Errorf("%s: expected redacted pin and the VIN, got %v", c.name, cmd.Params)
+		}
+	}
+	if n := len(v.client.DryRunLog().Commands()); n != len(commands) {
+		t.Errorf("expected %d recorded commands, got %d", len(commands), n)
+	}
+	if n := sent.Load(); n != 0 {
+		t.Errorf("expected no remote service requests, got %d", n)
+	}
+	if v.client.credentials.PIN != "1234" {
+		t.Error("expected redaction to leave the PIN itself alone")
+	}
+}
+
+// TestWithDryRun verifies a single call can be simulated on a live client and
+// still runs its validation.
+func TestWithDryRun(t *testing.T) {
+	v, sent := setupCountingVehicle(t, false)
+	ctx, log := WithDryRun(context.Background())
+
+	if _, err := v.EngineStartWithProfile(ctx, 7, 0, false, ""); err == nil {
+		t.Error("expected invalid run time to be rejected")
+	}
+	if _, err := v.UnlockDoors(ctx, UnlockDoor("trunk")); err == nil {
+		t.Error("expected unknown door to be rejected")
+	}
+	if n := len(log.Commands()); n != 0 {
+		t.Errorf("expected rejected commands not to be recorded, got %d", n)
+	}
+
+	if err := v.client.RemoteUnlock(ctx, v.Vin); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	cmd, ok := log.Last()
+	if !ok || !strings.HasSuffix(cmd.Endpoint, "/unlock/execute.json") || cmd.Params["pin"] != "[REDACTED]" {
+		t.Errorf("expected a redacted unlock command, got %+v", cmd)
+	}
+	if v.client.DryRunLog() != nil {
+		t.Error("expected no client dry-run log on a live client")
+	}
+	if n := sent.Load(); n != 0 {
+		t.Errorf("expected no remote service requests, got %d", n)
+	}
+}
+
+// TestDryRun_FenceSettings verifies settings saves are simulated like remote
+// commands.
+func TestDryRun_FenceSettings(t *testing.T) {
+	v, sent := setupCountingVehicle(t, false)
+	ctx, log := WithDryRun(context.Background())
+
+	if err := v.SaveSpeedFenceSettings(ctx, SpeedFenceSettings{Enabled: true, Limit: SpeedMPH(70)}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	cmd, ok := log.Last()
+	if !ok || !strings.HasSuffix(cmd.Endpoint, "/speedFence/save.json") || cmd.Params["speedLimit"] != "70" {
+		t.Errorf("expected the speed fence save to be recorded, got %+v", cmd)
+	}
+	if n := sent.Load(); n != 0 {
+		t.Errorf("expected no requests to the service, got %d", n)
+	}
+}
@@ -0,0 +1,286 @@
+package mysubaru
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// MaxChargeSchedules is the number of charge timer slots a PHEV head unit
+// holds. Adding a schedule beyond it fails with ErrNoSlotsLeft.
+const MaxChargeSchedules = 3
+
+// ChargeSchedule is one PHEV charge timer slot. The window runs from StartTime
+// to EndTime on each of DaysOfWeek; an EndTime before StartTime ends on the
+// following day.
+type ChargeSchedule struct {
+	ID         string `json:"scheduleId,omitempty"`
+	Enabled    bool   `json:"enabled"`
+	StartTime  string `json:"startTime"`  // HH:MM format
+	EndTime    string `json:"endTime"`    // HH:MM format
+	DaysOfWeek []int  `json:"daysOfWeek"` // 0=Sunday, 6=Saturday
+}
+
+// ChargeSettings is the PHEV charge timer configuration.
+type ChargeSettings struct {
+	Schedules  []ChargeSchedule `json:"timerSettings"`
+	AmpereType string           `json:"chargeSettingAmpereType,omitempty"` // EV_CHARGE_SETTING_AMPERE_TYPE
+}
+
+// Validate checks each schedule and that enabled schedules neither overlap nor
+// exceed MaxChargeSchedules.
+func (cs ChargeSettings) Validate() error {
+	if len(cs.Schedules) > MaxChargeSchedules {
+		return fmt.Errorf("%w: %d schedules, maximum is %d", ErrNoSlotsLeft, len(cs.Schedules), MaxChargeSchedules)
+	}
+	for i, s := range cs.Schedules {
+		if err := s.Validate(); err != nil {
+			return fmt.Errorf("charge schedule %d: %w", i+1, err)
+		}
+	}
+	for i := range cs.Schedules {
+		for j := i + 1; j < len(cs.Schedules); j++ {
+			a, b := cs.Schedules[i], cs.Schedules[j]
+			if a.Enabled && b.Enabled && a.overlaps(b) {
+				return fmt.Errorf("charge schedules %d and %d overlap", i+1, j+1)
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks the schedule's times and days.
+func (s ChargeSchedule) Validate() error {
+	if err := ValidateTimeRange(s.StartTime, s.EndTime); err != nil {
+		return fmt.Errorf("invalid time range: %w", err)
+	}
+	if clockMinutes(s.StartTime) == clockMinutes(s.EndTime) {
+		return errors.New("charge window start and end time must differ")
+	}
+	if len(s.DaysOfWeek) == 0 {
+		return errors.New("charge schedule needs at least one day of week")
+	}
+	if err := ValidateDaysOfWeek(s.DaysOfWeek); err != nil {
+		return fmt.Errorf("invalid days of week: %w", err)
+	}
+	return nil
+}
+
+// minutesPerWeek is the length of the weekly cycle charge windows repeat on.
+const minutesPerWeek = 7 * 24 * 60
+
+// windows returns the schedule's charge windows as [start, end) minute offsets
+// from Sunday 00:00. Windows wrapping past Saturday midnight are split.
+func (s ChargeSchedule) windows() [][2]int {
+	start, _ := time.Parse("15:04", s.StartTime)
+	end, _ := time.Parse("15:04", s.EndTime)
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	if to <= from {
+		to += 24 * 60
+	}
+
+	var ws [][2]int
+	for _, d := range s.DaysOfWeek {
+		lo, hi := d*24*60+from, d*24*60+to
+		if hi > minutesPerWeek {
+			ws = append(ws, [2]int{lo, minutesPerWeek}, [2]int{0, hi - minutesPerWeek})
+		} else {
+			ws = append(ws, [2]int{lo, hi})
+		}
+	}
+	return ws
+}
+
+// overlaps reports whether any charge window of s intersects one of o.
+func (s ChargeSchedule) overlaps(o ChargeSchedule) bool {
+	for _, a := range s.windows() {
+		for _, b := range o.windows() {
+			if a[0] < b[1] && b[0] < a[1] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetEVChargeSettings retrieves the EV charge timer settings from MySubaru and
+// stores them on EVStatus.ChargeSettings. Use RetrieveEVChargeTimer first to
+// read the current settings from the vehicle itself.
+func (v *Vehicle) GetEVChargeSettings(ctx context.Context) error {
+	if !v.IsEV() {
+		v.client.logger.Error("vehicle is not an EV")
+		return errors.New("vehicle is not an EV")
+	}
+
+	var raw json.RawMessage
+	if err := v.fetchInto(ctx, GET, "API_EV_FETCH_CHARGE_SETTINGS", map[string]string{}, false, &raw); err != nil {
+		return err
+	}
+	var cs ChargeSettings
+	// No timer configured comes back as a status string or null.
+	if !isJSONStringOrNull(raw) {
+		if err := json.Unmarshal(raw, &cs); err != nil {
+			v.client.logger.Error("error while parsing json", "request", "GetEVChargeSettings", "error", err.Error())
+			return err
+		}
+	}
+
+	v.mu.Lock()
+	v.EVStatus.ChargeSettings = cs
+	v.mu.Unlock()
+	return nil
+}
+
+// RetrieveEVChargeTimer asks the vehicle to report its charge timer settings to
+// MySubaru, waits for the request to finish, then refreshes
+// EVStatus.ChargeSettings.
+func (v *Vehicle) RetrieveEVChargeTimer(ctx context.Context) error {
+	if !v.IsEV() {
+		v.client.logger.Error("vehicle is not an EV")
+		return errors.New("vehicle is not an EV")
+	}
+
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return err
+	}
+	params := map[string]string{
+		"delay": "0",
+		"vin":   v.Vin,
+		"pin":   pin}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityEVCharge, "API_EV_RETRIEVE_TIMER")
+	if err != nil {
+		return err
+	}
+
+	ch, err := v.actuate(ctx, params, reqUrl, pollingUrl)
+	if err != nil {
+		return err
+	}
+	var last string
+	for state := range ch {
+		last = state
+	}
+	if last != "finished" {
+		return fmt.Errorf("retrieving charge timer from vehicle did not finish (last state %q)", last)
+	}
+	return v.GetEVChargeSettings(ctx)
+}
+
+// SaveEVChargeSettings validates and saves the full EV charge timer settings,
+// replacing all schedules, and stores them on EVStatus.ChargeSettings.
+func (v *Vehicle) SaveEVChargeSettings(ctx context.Context, settings ChargeSettings) error {
+	if !v.IsEV() {
+		v.client.logger.Error("vehicle is not an EV")
+		return errors.New("vehicle is not an EV")
+	}
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	schedules, err := json.Marshal(settings.Schedules)
+	if err != nil {
+		return err
+	}
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return err
+	}
+	params := map[string]string{
+		"vin":           v.Vin,
+		"pin":           pin,
+		"timerSettings": string(schedules),
+	}
+	if settings.AmpereType != "" {
+		params["chargeSettingAmpereType"] = settings.AmpereType
+	}
+	if err := v.fetchInto(ctx, POST, "API_EV_SAVE_CHARGE_SETTINGS", params, true, nil); err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	v.EVStatus.ChargeSettings = settings
+	v.mu.Unlock()
+	return nil
+}
+
+// AddEVChargeSchedule adds a schedule to the current settings and saves them.
+// It fails with ErrNoSlotsLeft when every slot is taken. A schedule without an
+// ID gets the lowest free slot number.
+func (v *Vehicle) AddEVChargeSchedule(ctx context.Context, schedule ChargeSchedule) error {
+	if err := v.GetEVChargeSettings(ctx); err != nil {
+		return err
+	}
+	cs := v.chargeSettings()
+	if len(cs.Schedules) >= MaxChargeSchedules {
+		return ErrNoSlotsLeft
+	}
+	if schedule.ID == "" {
+		for slot := 1; ; slot++ {
+			id := strconv.Itoa(slot)
+			if !slices.ContainsFunc(cs.Schedules, func(s ChargeSchedule) bool { return s.ID == id }) {
+				schedule.ID = id
+				break
+			}
+		}
+	}
+	cs.Schedules = append(cs.Schedules, schedule)
+	return v.SaveEVChargeSettings(ctx, cs)
+}
+
+// UpdateEVChargeSchedule replaces the schedule with the same ID and saves the
+// settings.
+func (v *Vehicle) UpdateEVChargeSchedule(ctx context.Context, schedule ChargeSchedule) error {
+	if err := v.GetEVChargeSettings(ctx); err != nil {
+		return err
+	}
+	cs := v.chargeSettings()
+	i := slices.IndexFunc(cs.Schedules, func(s ChargeSchedule) bool { return s.ID == schedule.ID })
+	if i < 0 {
+		return fmt.Errorf("charge schedule %q not found", schedule.ID)
+	}
+	cs.Schedules[i] = schedule
+	return v.SaveEVChargeSettings(ctx, cs)
+}
+
+// DeleteEVChargeSchedule deletes the EV charge schedule with the given ID and
+// removes it from EVStatus.ChargeSettings.
+func (v *Vehicle) DeleteEVChargeSchedule(ctx context.Context, scheduleID string) error {
+	if !v.IsEV() {
+		v.client.logger.Error("vehicle is not an EV")
+		return errors.New("vehicle is not an EV")
+	}
+
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return err
+	}
+	params := map[string]string{
+		"vin":        v.Vin,
+		"pin":        pin,
+		"scheduleId": scheduleID,
+	}
+	if err := v.fetchInto(ctx, POST, "API_EV_DELETE_CHARGE_SCHEDULE", params, true, nil); err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	v.EVStatus.ChargeSettings.Schedules = slices.DeleteFunc(v.EVStatus.ChargeSettings.Schedules,
+		func(s ChargeSchedule) bool { return s.ID == scheduleID })
+	v.mu.Unlock()
+	return nil
+}
+
+// chargeSettings returns a copy of the stored charge settings.
+func (v *Vehicle) chargeSettings() ChargeSettings {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	cs := v.EVStatus.ChargeSettings
+	cs.Schedules = slices.Clone(cs.Schedules)
+	return cs
+}
@@ -0,0 +1,99 @@
+package mysubaru
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// TestChargeSettingsValidate covers schedule validation, overlapping windows
+// (including overnight and week-wrapping ones) and the slot limit.
+func TestChargeSettingsValidate(t *testing.T) {
+	weeknights := ChargeSchedule{ID: "1", Enabled: true, StartTime: "23:00", EndTime: "06:00", DaysOfWeek: []int{1, 2, 3, 4, 5}}
+
+	tests := []struct {
+		name    string
+		s       []ChargeSchedule
+		wantErr bool
+	}{
+		{"single overnight", []ChargeSchedule{weeknights}, false},
+		{"adjacent windows", []ChargeSchedule{weeknights, {ID: "2", Enabled: true, StartTime: "06:00", EndTime: "08:00", DaysOfWeek: []int{2}}}, false},
+		{"overnight spills into next day", []ChargeSchedule{weeknights, {ID: "2", Enabled: true, StartTime: "05:00", EndTime: "07:00", DaysOfWeek: []int{6}}}, true},
+		{"saturday night wraps to sunday", []ChargeSchedule{
+			{ID: "1", Enabled: true, StartTime: "22:00", EndTime: "02:00", DaysOfWeek: []int{6}},
+			{ID: "2", Enabled: true, StartTime: "01:00", EndTime: "03:00", DaysOfWeek: []int{0}},
+		}, true},
+		{"disabled schedules may overlap", []ChargeSchedule{weeknights, {ID: "2", StartTime: "00:00", EndTime: "05:00", DaysOfWeek: []int{2}}}, false},
+		{"empty days", []ChargeSchedule{{StartTime: "01:00", EndTime: "02:00"}}, true},
+		{"bad time", []ChargeSchedule{{StartTime: "25:00", EndTime: "02:00", DaysOfWeek: []int{1}}}, true},
+		{"zero length", []ChargeSchedule{{StartTime: "02:00", EndTime: "02:00", DaysOfWeek: []int{1}}}, true},
+		{"zero length without leading zero", []ChargeSchedule{{StartTime: "7:00", EndTime: "07:00", DaysOfWeek: []int{1}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ChargeSettings{Schedules: tt.s}.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	full := ChargeSettings{Schedules: make([]ChargeSchedule, MaxChargeSchedules+1)}
+	if err := full.Validate(); !errors.Is(err, ErrNoSlotsLeft) {
+		t.Errorf("expected ErrNoSlotsLeft, got %v", err)
+	}
+}
+
+// TestEVChargeSchedules_CRUD fetches, adds and deletes schedules against the
+// mock server and checks EVStatus.ChargeSettings follows along.
+func TestEVChargeSchedules_CRUD(t *testing.T) {
+	routes := append(standardTestRoutes(),
+		endpointRoute{Method: http.MethodGet, Path: apiURLs["API_EV_FETCH_CHARGE_SETTINGS"], Response: `{"success":true,"errorCode":null,"dataName":null,"data":{"timerSettings":[{"scheduleId":"1","enabled":true,"startTime":"23:00","endTime":"06:00","daysOfWeek":[1,2,3,4,5]}],"chargeSettingAmpereType":"MAXIMUM"}}`},
+		endpointRoute{Method: http.MethodPost, Path: apiURLs["API_EV_SAVE_CHARGE_SETTINGS"], Response: `{"success":true,"errorCode":null,"dataName":null,"data":null}`},
+		endpointRoute{Method: http.MethodPost, Path: apiURLs["API_EV_DELETE_CHARGE_SCHEDULE"], Response: `{"success":true,"errorCode":null,"dataName":null,"data":null}`},
+	)
+	ts := mockServerWithRoutes(t, routes)
+	ts.Start()
+	defer ts.Close()
+
+	msc, err := New(mockConfig(t))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ok, _, err := msc.Authenticate(context.Background()); !ok || err != nil {
+		t.Fatalf("expected authentication to succeed, got ok=%v, err=%v", ok, err)
+	}
+	v, err := msc.GetVehicleByVin(context.Background(), "1HGCM82633A004352")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	v.Features = append(v.Features, FEATURE_PHEV)
+
+	if err := v.GetEVChargeSettings(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cs := v.EVStatus.ChargeSettings; len(cs.Schedules) != 1 || cs.Schedules[0].StartTime != "23:00" || cs.AmpereType != "MAXIMUM" {
+		t.Fatalf("unexpected charge settings: %+v", cs)
+	}
+
+	overlapping := ChargeSchedule{Enabled: true, StartTime: "05:00", EndTime: "07:00", DaysOfWeek: []int{2}}
+	if err := v.AddEVChargeSchedule(context.Background(), overlapping); err == nil {
+		t.Fatal("expected overlapping schedule to be rejected")
+	}
+
+	weekend := ChargeSchedule{Enabled: true, StartTime: "10:00", EndTime: "14:00", DaysOfWeek: []int{0, 6}}
+	if err := v.AddEVChargeSchedule(context.Background(), weekend); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cs := v.EVStatus.ChargeSettings; len(cs.Schedules) != 2 || cs.Schedules[1].ID != "2" {
+		t.Fatalf("expected new schedule in slot 2, got %+v", cs.Schedules)
+	}
+
+	if err := v.DeleteEVChargeSchedule(context.Background(), "1"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cs := v.EVStatus.ChargeSettings; len(cs.Schedules) != 1 || cs.Schedules[0].ID != "2" {
+		t.Errorf("expected only schedule 2 left, got %+v", cs.Schedules)
+	}
+}
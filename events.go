@@ -0,0 +1,226 @@
+package mysubaru
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+const (
+	// DefaultLowFuelPercent is the remaining fuel, in percent, at or below
+	// which EventLowFuel is raised.
+	DefaultLowFuelPercent = 15
+	// DefaultLocationMoveMeters is how far, in meters, the vehicle must move
+	// between two reported positions to raise EventLocationMoved.
+	DefaultLocationMoveMeters = 100.0
+
+	// eventBufferSize is the number of events a subscription buffers before
+	// further events for it are dropped.
+	eventBufferSize = 32
+)
+
+// VehicleEventType is the kind of state change a VehicleEvent reports.
+type VehicleEventType string
+
+const (
+	EventDoorOpened      VehicleEventType = "door_opened"      // a door, the boot or the hood opened
+	EventDoorClosed      VehicleEventType = "door_closed"      // a door, the boot or the hood closed
+	EventLockChanged     VehicleEventType = "lock_changed"     // a door was locked or unlocked
+	EventWindowChanged   VehicleEventType = "window_changed"   // a window or the sunroof moved
+	EventIgnitionOn      VehicleEventType = "ignition_on"      // the ignition was switched on
+	EventIgnitionOff     VehicleEventType = "ignition_off"     // the ignition was switched off
+	EventLowFuel         VehicleEventType = "low_fuel"         // the fuel level dropped to the low fuel threshold
+	EventTroubleAppeared VehicleEventType = "trouble_appeared" // a vehicle health item reported trouble
+	EventLocationMoved   VehicleEventType = "location_moved"   // the reported position moved
+)
+
+// VehicleEvent is a change in a vehicle's state, observed when
+// GetVehicleStatus, GetVehicleCondition, GetVehicleHealth, RefreshStatus or a
+// vehicle selection updates it. The embedded Change holds the field and its
+// old and new values, e.g. "Doors[door_rear_left].Status" from DoorClosed to
+// DoorOpen, or "GeoLocation" between two LatLng positions.
+type VehicleEvent struct {
+	Type     VehicleEventType
+	Vin      string
+	Snapshot VehicleSnapshot // the vehicle's state after the change
+	Change
+}
+
+func (e VehicleEvent) String() string {
+	return fmt.Sprintf("%s %s %s", e.Vin, e.Type, e.Change)
+}
+
+// EventFilter selects the events a subscription receives. Empty fields match
+// everything.
+type EventFilter struct {
+	Vins  []string
+	Types []VehicleEventType
+}
+
+func (f EventFilter) matches(e VehicleEvent) bool {
+	return (len(f.Vins) == 0 || slices.Contains(f.Vins, e.Vin)) &&
+		(len(f.Types) == 0 || slices.Contains(f.Types, e.Type))
+}
+
+// EventOptions configures the thresholds of the derived events. Zero values
+// use the defaults.
+type EventOptions struct {
+	LowFuelPercent int     // default DefaultLowFuelPercent
+	MoveMeters     float64 // default DefaultLocationMoveMeters
+}
+
+// eventBus fans vehicle events out to the client's subscriptions. The zero
+// value is ready to use.
+type eventBus struct {
+	mu   sync.Mutex
+	subs map[*subscription]struct{}
+	opts EventOptions
+}
+
+type subscription struct {
+	filter EventFilter
+	ch     chan VehicleEvent
+}
+
+// Subscribe returns a channel receiving the events of the client's vehicles
+// that match filter, until ctx is done, when the channel is closed. Events
+// are buffered; those arriving while a slow subscriber's buffer is full are
+// dropped rather than holding up the update that raised them.
+func (c *Client) Subscribe(ctx context.Context, filter EventFilter) <-chan VehicleEvent {
+	s := &subscription{filter: filter, ch: make(chan VehicleEvent, eventBufferSize)}
+	b := &c.events
+	b.mu.Lock()
+	if b.subs == nil {
+		b.subs = make(map[*subscription]struct{})
+	}
+	b.subs[s] = struct{}{}
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		delete(b.subs, s)
+		close(s.ch)
+		b.mu.Unlock()
+	}()
+	return s.ch
+}
+
+// SetEventOptions sets the thresholds of the low fuel and location events.
+func (c *Client) SetEventOptions(opts EventOptions) {
+	c.events.mu.Lock()
+	defer c.events.mu.Unlock()
+	c.events.opts = opts
+}
+
+// Watch returns a channel receiving v's events of the given types, or of every
+// type when none are given, until ctx is done. See Client.Subscribe.
+func (v *Vehicle) Watch(ctx context.Context, types ...VehicleEventType) <-chan VehicleEvent {
+	return v.client.Subscribe(ctx, EventFilter{Vins: []string{v.Vin}, Types: types})
+}
+
+// watching reports whether any subscription may want vin's events.
+func (b *eventBus) watching(vin string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subs {
+		if len(s.filter.Vins) == 0 || slices.Contains(s.filter.Vins, vin) {
+			return true
+		}
+	}
+	return false
+}
+
+// publish sends the events raised between two snapshots to the matching
+// subscriptions without blocking.
+func (b *eventBus) publish(before, after VehicleSnapshot) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, e := range vehicleEvents(before, after, b.opts) {
+		for s := range b.subs {
+			if !s.filter.matches(e) {
+				continue
+			}
+			select {
+			case s.ch <- e:
+			default:
+			}
+		}
+	}
+}
+
+// trackChanges snapshots v ahead of an update and returns a func that
+// publishes the events the update raised. Callers hold v.mu across both calls,
+// typically with
+//
+//	defer v.trackChanges()()
+//
+// after taking the lock. Without subscriptions for v it does nothing.
+func (v *Vehicle) trackChanges() func() {
+	if v.client == nil || !v.client.events.watching(v.Vin) {
+		return func() {}
+	}
+	before := v.snapshot()
+	return func() { v.client.events.publish(before, v.snapshot()) }
+}
+
+// vehicleEvents derives the events raised between two snapshots of a vehicle.
+// Doors, windows, locks and the ignition only raise events when both states
+// are known, and troubles only once health has been reported (Troubles is
+// non-nil), so the first report of a vehicle doesn't read as a change.
+func vehicleEvents(before, after VehicleSnapshot, opts EventOptions) []VehicleEvent {
+	var events []VehicleEvent
+	event := func(t VehicleEventType, c Change) {
+		events = append(events, VehicleEvent{Type: t, Vin: after.Vin, Snapshot: after, Change: c})
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(after.Doors)) {
+		b, a := before.Doors[name], after.Doors[name]
+		if b.Status != DoorStatusUnknown && a.Status != DoorStatusUnknown && b.Status != a.Status {
+			t := EventDoorClosed
+			if a.Status == DoorOpen {
+				t = EventDoorOpened
+			}
+			event(t, Change{Field: "Doors[" + name + "].Status", Old: b.Status, New: a.Status})
+		}
+		if b.Lock != LockStatusUnknown && a.Lock != LockStatusUnknown && b.Lock != a.Lock {
+			event(EventLockChanged, Change{Field: "Doors[" + name + "].Lock", Old: b.Lock, New: a.Lock})
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(after.Windows)) {
+		b, a := before.Windows[name], after.Windows[name]
+		if b.Status != WindowStatusUnknown && a.Status != WindowStatusUnknown && b.Status != a.Status {
+			event(EventWindowChanged, Change{Field: "Windows[" + name + "].Status", Old: b.Status, New: a.Status})
+		}
+	}
+
+	if before.EngineState != IgnitionUnknown && before.EngineState != after.EngineState {
+		switch after.EngineState {
+		case IgnitionOn:
+			event(EventIgnitionOn, Change{Field: "EngineState", Old: before.EngineState, New: after.EngineState})
+		case IgnitionOff:
+			event(EventIgnitionOff, Change{Field: "EngineState", Old: before.EngineState, New: after.EngineState})
+		}
+	}
+
+	low := cmp.Or(opts.LowFuelPercent, DefaultLowFuelPercent)
+	if b, a := before.DistanceToEmpty.Percentage, after.DistanceToEmpty.Percentage; b > low && a > 0 && a <= low {
+		event(EventLowFuel, Change{Field: "DistanceToEmpty.Percentage", Old: b, New: a})
+	}
+
+	for _, code := range slices.Sorted(maps.Keys(after.Troubles)) {
+		if _, ok := before.Troubles[code]; !ok && before.Troubles != nil {
+			event(EventTroubleAppeared, Change{Field: "Troubles[" + code + "]", New: after.Troubles[code]})
+		}
+	}
+
+	from := LatLng{Lat: before.GeoLocation.Latitude, Lng: before.GeoLocation.Longitude}
+	to := LatLng{Lat: after.GeoLocation.Latitude, Lng: after.GeoLocation.Longitude}
+	if from != (LatLng{}) && to != (LatLng{}) && DistanceBetween(from, to) >= cmp.Or(opts.MoveMeters, DefaultLocationMoveMeters) {
+		event(EventLocationMoved, Change{Field: "GeoLocation", Old: from, New: to})
+	}
+	return events
+}
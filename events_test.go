@@ -0,0 +1,99 @@
+package mysubaru
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestVehicleEvents verifies the events derived from two snapshots.
+func TestVehicleEvents(t *testing.T) {
+	before := VehicleSnapshot{
+		Vin:         "1HGCM82633A004352",
+		EngineState: IgnitionOff,
+		Doors: map[string]Door{
+			"door_front_left": {Status: DoorClosed, Lock: DoorLocked},
+			"door_boot":       {Status: DoorStatusUnknown},
+		},
+		Windows:     map[string]Window{"window_sunroof": {Status: WindowClosed}},
+		Troubles:    map[string]Trouble{"OPL_MIL": {Description: "Oil Pressure"}},
+		GeoLocation: GeoLocation{Latitude: 40.7128, Longitude: -74.006},
+	}
+	before.DistanceToEmpty.Percentage = 20
+	after := VehicleSnapshot{
+		Vin:         before.Vin,
+		EngineState: IgnitionOn,
+		Doors: map[string]Door{
+			"door_front_left": {Status: DoorOpen, Lock: DoorUnlocked},
+			"door_boot":       {Status: DoorOpen},
+		},
+		Windows:     map[string]Window{"window_sunroof": {Status: WindowVented}},
+		Troubles:    map[string]Trouble{"OPL_MIL": {Description: "Oil Pressure"}, "ABS_MIL": {Description: "ABS"}},
+		GeoLocation: GeoLocation{Latitude: 40.7228, Longitude: -74.006},
+	}
+	after.DistanceToEmpty.Percentage = 12
+
+	want := []VehicleEventType{EventDoorOpened, EventLockChanged, EventWindowChanged, EventIgnitionOn, EventLowFuel, EventTroubleAppeared, EventLocationMoved}
+	events := vehicleEvents(before, after, EventOptions{})
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %v", len(want), events)
+	}
+	for i, e := range events {
+		if e.Type != want[i] || e.Vin != before.Vin {
+			t.Errorf("event %d: expected %s, got %v", i, want[i], e)
+		}
+	}
+	if c := events[0].Change; c.Field != "Doors[door_front_left].Status" || c.Old != DoorClosed || c.New != DoorOpen {
+		t.Errorf("unexpected door change: %v", c)
+	}
+
+	if events := vehicleEvents(before, after, EventOptions{LowFuelPercent: 10, MoveMeters: 5000}); len(events) != 5 {
+		t.Errorf("expected the raised thresholds to drop the fuel and location events, got %v", events)
+	}
+	if events := vehicleEvents(after, after, EventOptions{}); len(events) != 0 {
+		t.Errorf("expected no events without changes, got %v", events)
+	}
+	if events := vehicleEvents(VehicleSnapshot{Vin: before.Vin}, after, EventOptions{}); len(events) != 0 {
+		t.Errorf("expected no events for the first health report, got %v", events)
+	}
+}
+
+// TestWatch verifies subscriptions receive the matching events of status
+// updates and are closed with their context.
+func TestWatch(t *testing.T) {
+	v, _ := setupCountingVehicle(t, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	all := v.client.Subscribe(ctx, EventFilter{})
+	ignition := v.Watch(ctx, EventIgnitionOff)
+	other := v.client.Subscribe(ctx, EventFilter{Vins: []string{"JF1ZZZZZZZZZZZZZZ"}})
+
+	// The status report has the ignition off.
+	v.mu.Lock()
+	v.EngineState = IgnitionOn
+	v.mu.Unlock()
+	if err := v.GetVehicleStatus(ctx); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	select {
+	case e := <-ignition:
+		if e.Type != EventIgnitionOff || e.Old != IgnitionOn || e.Snapshot.EngineState != IgnitionOff {
+			t.Errorf("unexpected event: %v", e)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected an ignition event")
+	}
+	if len(all) == 0 {
+		t.Error("expected the unfiltered subscription to receive the event")
+	}
+	select {
+	case e := <-other:
+		t.Errorf("expected no events for another vehicle, got %v", e)
+	default:
+	}
+
+	cancel()
+	for range ignition {
+	}
+}
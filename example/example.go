@@ -268,7 +268,7 @@ func main() {
 	// 	homeLng := -74.0060
 	// 	homeRadius := 500 // 500 meters
 
-	// 	ch, err := vehicle.SetGeoFence(ctx, homeLat, homeLng, homeRadius, "Home", true, true, true)
+	// 	ch, err := vehicle.CreateGeoFence(ctx, mysubaru.GeoFence{Name: "Home", Latitude: homeLat, Longitude: homeLng, Radius: homeRadius, Enabled: true, AlertOnEntry: true, AlertOnExit: true})
 	// 	if err != nil {
 	// 		log.Printf("Geofence setup failed: %v", err)
 	// 	} else {
@@ -278,7 +278,7 @@ func main() {
 
 	// 	// Set up speed fence
 	// 	fmt.Println("🚦 Setting up speed fence...")
-	// 	ch, err = vehicle.SetSpeedFence(ctx, 65, true, true) // 65 mph limit
+	// 	ch, err = vehicle.SetSpeedFence(ctx, mysubaru.SpeedFenceSettings{Enabled: true, Limit: mysubaru.SpeedMPH(65)}, true)
 	// 	if err != nil {
 	// 		log.Printf("Speed fence setup failed: %v", err)
 	// 	} else {
@@ -288,8 +288,8 @@ func main() {
 
 	// 	// Set up curfew
 	// 	fmt.Println("🌙 Setting up curfew...")
-	// 	daysOfWeek := []int{1, 2, 3, 4, 5} // Monday to Friday
-	// 	ch, err = vehicle.SetCurfew(ctx, "22:00", "06:00", daysOfWeek, true)
+	// 	weeknights := []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday}
+	// 	ch, err = vehicle.SetCurfew(ctx, mysubaru.CurfewSettings{Enabled: true, Windows: []mysubaru.CurfewWindow{{Start: "22:00", End: "06:00", Days: weeknights}}})
 	// 	if err != nil {
 	// 		log.Printf("Curfew setup failed: %v", err)
 	// 	} else {
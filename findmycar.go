@@ -0,0 +1,154 @@
+package mysubaru
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// FindMyCarMode selects what the vehicle does during each find-my-car burst.
+type FindMyCarMode int
+
+const (
+	// FindMyCarHornLights sounds the horn and flashes the lights.
+	FindMyCarHornLights FindMyCarMode = iota
+	// FindMyCarLightsOnly flashes the lights without the horn.
+	FindMyCarLightsOnly
+)
+
+const (
+	DefaultFindMyCarBursts        = 3
+	DefaultFindMyCarInterval      = 20 * time.Second
+	DefaultFindMyCarBurstDuration = 10 * time.Second
+
+	// findMyCarStopTimeout bounds the stop command sent after the caller's
+	// context is cancelled, which can no longer be used for the request.
+	findMyCarStopTimeout = 30 * time.Second
+)
+
+// FindMyCarOptions configures a find-my-car routine. Zero values fall back to
+// the package defaults (three bursts, 20 seconds apart, 10 seconds each).
+type FindMyCarOptions struct {
+	Mode          FindMyCarMode
+	Bursts        int           // number of bursts to run
+	Interval      time.Duration // time between the starts of consecutive bursts
+	BurstDuration time.Duration // how long each burst runs before it is stopped
+}
+
+// FindMyCarEvent reports the progress of a single find-my-car burst.
+type FindMyCarEvent struct {
+	Burst            int    // 1-based burst number
+	ServiceRequestID string // remote service request ID of the burst
+	State            string // started | stopped | error
+	Err              error  // set when State is "error"
+}
+
+// withDefaults fills zero-valued options and validates the schedule.
+func (o FindMyCarOptions) withDefaults() (FindMyCarOptions, error) {
+	if o.Bursts == 0 {
+		o.Bursts = DefaultFindMyCarBursts
+	}
+	if o.Interval == 0 {
+		o.Interval = DefaultFindMyCarInterval
+	}
+	if o.BurstDuration == 0 {
+		o.BurstDuration = min(DefaultFindMyCarBurstDuration, o.Interval)
+	}
+	switch {
+	case o.Mode != FindMyCarHornLights && o.Mode != FindMyCarLightsOnly:
+		return o, fmt.Errorf("unknown find-my-car mode %d", o.Mode)
+	case o.Bursts < 0:
+		return o, fmt.Errorf("bursts must be positive, got %d", o.Bursts)
+	case o.Interval < 0 || o.BurstDuration < 0:
+		return o, errors.New("interval and burst duration must be positive")
+	case o.BurstDuration > o.Interval:
+		return o, fmt.Errorf("burst duration %s exceeds interval %s", o.BurstDuration, o.Interval)
+	}
+	return o, nil
+}
+
+// FindMyCar runs a find-my-car routine: a series of horn+lights (or
+// lights-only) bursts on the configured schedule. Each burst is started, left
+// running for BurstDuration, and stopped by its service request ID through the
+// matching stop endpoint. Cancelling ctx stops the running burst cleanly and
+// ends the routine. The returned channel receives one event per state change
+// and is closed when the routine ends. Works on G1 and G2 vehicles.
+func (v *Vehicle) FindMyCar(ctx context.Context, opts FindMyCarOptions) (<-chan FindMyCarEvent, error) {
+	opts, err := opts.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	startKey, stopKey := "API_HORN_LIGHTS", "API_HORN_LIGHTS_STOP"
+	if opts.Mode == FindMyCarLightsOnly {
+		startKey, stopKey = "API_LIGHTS", "API_LIGHTS_STOP"
+	}
+	startUrl, _, err := v.commandURLs(CapabilityHornLights, startKey)
+	if err != nil {
+		return nil, err
+	}
+	stopUrl, _, err := v.commandURLs(CapabilityHornLights, stopKey)
+	if err != nil {
+		return nil, err
+	}
+	// The PIN is asked for once and reused by every burst.
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Two events per burst (started + stopped/error) never block the sender.
+	ch := make(chan FindMyCarEvent, 2*opts.Bursts)
+	go func() {
+		defer close(ch)
+		for burst := 1; burst <= opts.Bursts; burst++ {
+			burstStart := time.Now()
+			params := map[string]string{
+				"delay": "0",
+				"vin":   v.Vin,
+				"pin":   pin}
+			sr, err := v.sendServiceRequest(ctx, params, startUrl)
+			if err != nil {
+				ch <- FindMyCarEvent{Burst: burst, State: "error", Err: err}
+				return
+			}
+			ch <- FindMyCarEvent{Burst: burst, ServiceRequestID: sr.ServiceRequestID, State: "started"}
+
+			cancelled := sleepCtx(ctx, opts.BurstDuration) != nil
+			if err := v.stopFindMyCarBurst(ctx, stopUrl, sr.ServiceRequestID, pin); err != nil {
+				ch <- FindMyCarEvent{Burst: burst, ServiceRequestID: sr.ServiceRequestID, State: "error", Err: err}
+				return
+			}
+			ch <- FindMyCarEvent{Burst: burst, ServiceRequestID: sr.ServiceRequestID, State: "stopped"}
+			if cancelled || burst == opts.Bursts {
+				return
+			}
+			if sleepCtx(ctx, opts.Interval-time.Since(burstStart)) != nil {
+				return
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// stopFindMyCarBurst stops a burst by its service request ID and waits for the
+// stop command to reach a terminal state. It keeps working after ctx is
+// cancelled so a cancelled routine never leaves the horn sounding.
+func (v *Vehicle) stopFindMyCarBurst(ctx context.Context, stopUrl, serviceRequestID, pin string) error {
+	stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), findMyCarStopTimeout)
+	defer cancel()
+
+	ch, err := v.actuate(stopCtx, v.stopParams(serviceRequestID, pin), stopUrl, v.statusURL(CapabilityHornLights))
+	if err != nil {
+		return err
+	}
+	var last string
+	for state := range ch {
+		last = state
+	}
+	if last == "error" {
+		return fmt.Errorf("failed to stop find-my-car request %s", serviceRequestID)
+	}
+	return nil
+}
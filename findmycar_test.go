@@ -0,0 +1,153 @@
+package mysubaru
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	testFindMyCarStartedResponse  = `{"success":true,"errorCode":null,"dataName":"remoteServiceStatus","data":{"serviceRequestId":"1HGCM82633A004352_1640294426029_19_@NGTP","success":false,"cancelled":false,"remoteServiceType":"hornLights","remoteServiceState":"started","subState":null,"errorCode":null,"result":null,"updateTime":null,"vin":"1HGCM82633A004352","errorDescription":null}}`
+	testFindMyCarFinishedResponse = `{"success":true,"errorCode":null,"dataName":"remoteServiceStatus","data":{"serviceRequestId":null,"success":true,"cancelled":false,"remoteServiceType":"hornLights","remoteServiceState":"finished","subState":null,"errorCode":null,"result":null,"updateTime":null,"vin":"1HGCM82633A004352","errorDescription":null}}`
+)
+
+// findMyCarTestVehicle starts a mock server with horn/lights routes and returns
+// an authenticated vehicle.
+func findMyCarTestVehicle(t *testing.T) *Vehicle {
+	t.Helper()
+
+	routes := append(standardTestRoutes(),
+		endpointRoute{Method: http.MethodPost, Path: apiURLs["API_HORN_LIGHTS"], Response: testFindMyCarStartedResponse},
+		endpointRoute{Method: http.MethodPost, Path: apiURLs["API_LIGHTS"], Response: testFindMyCarStartedResponse},
+		endpointRoute{Method: http.MethodPost, Path: apiURLs["API_HORN_LIGHTS_STOP"], Response: testFindMyCarFinishedResponse},
+		endpointRoute{Method: http.MethodPost, Path: apiURLs["API_LIGHTS_STOP"], Response: testFindMyCarFinishedResponse},
+	)
+	ts := mockServerWithRoutes(t, routes)
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	msc, err := New(mockConfig(t))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ok, _, err := msc.Authenticate(context.Background()); !ok || err != nil {
+		t.Fatalf("expected authentication to succeed, got ok=%v, err=%v", ok, err)
+	}
+	v, err := msc.GetVehicleByVin(context.Background(), "1HGCM82633A004352")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return v
+}
+
+// TestFindMyCar_Bursts verifies each burst is started and then stopped by its
+// service request ID, for the configured number of bursts.
+func TestFindMyCar_Bursts(t *testing.T) {
+	v := findMyCarTestVehicle(t)
+
+	for _, mode := range []FindMyCarMode{FindMyCarHornLights, FindMyCarLightsOnly} {
+		ch, err := v.FindMyCar(context.Background(), FindMyCarOptions{
+			Mode:          mode,
+			Bursts:        2,
+			Interval:      20 * time.Millisecond,
+			BurstDuration: 5 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("mode %d: expected no error, got %v", mode, err)
+		}
+
+		var got []FindMyCarEvent
+		for ev := range ch {
+			got = append(got, ev)
+		}
+		want := []struct {
+			burst int
+			state string
+		}{{1, "started"}, {1, "stopped"}, {2, "started"}, {2, "stopped"}}
+		if len(got) != len(want) {
+			t.Fatalf("mode %d: expected %d events, got %d: %+v", mode, len(want), len(got), got)
+		}
+		for i, w := range want {
+			if got[i].Burst != w.burst || got[i].State != w.state {
+				t.Errorf("mode %d event %d: expected burst %d %s, got %+v", mode, i, w.burst, w.state, got[i])
+			}
+			if got[i].ServiceRequestID != "1HGCM82633A004352_1640294426029_19_@NGTP" {
+				t.Errorf("mode %d event %d: unexpected service request ID %q", mode, i, got[i].ServiceRequestID)
+			}
+		}
+	}
+}
+
+// TestFindMyCar_Cancel verifies cancelling the context stops the running burst
+// and ends the routine early.
+func TestFindMyCar_Cancel(t *testing.T) {
+	v := findMyCarTestVehicle(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := v.FindMyCar(ctx, FindMyCarOptions{Bursts: 5, Interval: time.Minute, BurstDuration: time.Minute})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ev := <-ch; ev.State != "started" {
+		t.Fatalf("expected started event, got %+v", ev)
+	}
+	cancel()
+
+	var states []string
+	for ev := range ch {
+		states = append(states, ev.State)
+	}
+	if len(states) != 1 || states[0] != "stopped" {
+		t.Errorf("expected a single stopped event after cancel, got %v", states)
+	}
+}
+
+// TestFindMyCar_Rejected verifies a start the backend rejects ends the routine
+// with the parsed error, without a started event or a stop request.
+func TestFindMyCar_Rejected(t *testing.T) {
+	rejected := `{"success":true,"errorCode":null,"dataName":"remoteServiceStatus","data":{"serviceRequestId":null,"success":false,"cancelled":false,"remoteServiceType":"hornLights","remoteServiceState":"finished","subState":null,"errorCode":"NegativeAcknowledge_doorNotClosed","result":null,"updateTime":null,"vin":"1HGCM82633A004352","errorDescription":null}}`
+	v, sent := setupCountingVehicle(t, false,
+		endpointRoute{Method: http.MethodPost, Path: apiURLs["API_HORN_LIGHTS"], Response: rejected},
+		endpointRoute{Method: http.MethodPost, Path: apiURLs["API_HORN_LIGHTS_STOP"], Response: testFindMyCarFinishedResponse},
+	)
+
+	ch, err := v.FindMyCar(context.Background(), FindMyCarOptions{Bursts: 2, Interval: 20 * time.Millisecond, BurstDuration: 5 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var got []FindMyCarEvent
+	for ev := range ch {
+		got = append(got, ev)
+	}
+	if len(got) != 1 || got[0].State != "error" || !errors.Is(got[0].Err, ErrDoorNotClosed) {
+		t.Fatalf("expected a single door-not-closed error, got %+v", got)
+	}
+	if n := sent.Load(); n != 1 {
+		t.Errorf("expected only the start request, got %d requests", n)
+	}
+}
+
+// TestFindMyCarOptions_Validation verifies defaults and schedule validation.
+func TestFindMyCarOptions_Validation(t *testing.T) {
+	opts, err := FindMyCarOptions{}.withDefaults()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if opts.Bursts != DefaultFindMyCarBursts || opts.Interval != DefaultFindMyCarInterval || opts.BurstDuration != DefaultFindMyCarBurstDuration {
+		t.Errorf("unexpected defaults: %+v", opts)
+	}
+
+	invalid := []FindMyCarOptions{
+		{Mode: FindMyCarMode(9)},
+		{Bursts: -1},
+		{Interval: -time.Second},
+		{Interval: time.Second, BurstDuration: 2 * time.Second},
+	}
+	for _, o := range invalid {
+		if _, err := o.withDefaults(); err == nil {
+			t.Errorf("expected error for %+v", o)
+		}
+	}
+}
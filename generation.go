@@ -0,0 +1,187 @@
+package mysubaru
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// =============================================================================
+// Telematics Generations
+// =============================================================================
+
+// Capability is a remote feature whose availability and endpoints depend on
+// the vehicle's telematics generation.
+type Capability string
+
+const (
+	CapabilityLock          Capability = "lock"          // lock, unlock and their cancels
+	CapabilityHornLights    Capability = "hornLights"    // horn and lights, lights only
+	CapabilityLocate        Capability = "locate"        // real-time locate
+	CapabilityEngineStart   Capability = "engineStart"   // remote engine start and stop
+	CapabilityEVCharge      Capability = "evCharge"      // charge now and charge timers
+	CapabilityGeoFence      Capability = "geoFence"      // geofence alerts
+	CapabilitySpeedFence    Capability = "speedFence"    // speed alerts
+	CapabilityCurfew        Capability = "curfew"        // curfew alerts
+	CapabilityValet         Capability = "valet"         // valet mode
+	CapabilityTripLog       Capability = "tripLog"       // trip tracker
+	CapabilitySendPOI       Capability = "sendPoi"       // send destination to the head unit
+	CapabilityStatusRefresh Capability = "statusRefresh" // on-demand status refresh
+)
+
+// g1Capabilities lists what G1 (SiriusXM) telematics supports. Every
+// capability is available from G2 on.
+var g1Capabilities = []Capability{CapabilityLock, CapabilityHornLights, CapabilityLocate}
+
+// statusEndpoints maps a generation and capability to the apiURLs key of the
+// endpoint its commands are polled on. Anything not listed polls
+// API_REMOTE_SVC_STATUS.
+var statusEndpoints = map[string]map[Capability]string{
+	FEATURE_G1_TELEMATICS: {
+		CapabilityHornLights: "API_G1_HORN_LIGHTS_STATUS",
+		CapabilityLocate:     "API_G1_LOCATE_STATUS",
+	},
+	FEATURE_G2_TELEMATICS: {
+		CapabilityLocate:        "API_G2_LOCATE_STATUS",
+		CapabilitySendPOI:       "API_G2_SEND_POI_STATUS",
+		CapabilityStatusRefresh: "API_G2_VEHICLE_CONDITION_STATUS",
+	},
+}
+
+// endpointOverrides maps a generation and apiURLs key to the key used instead
+// where the path differs by more than the generation segment.
+var endpointOverrides = map[string]map[string]string{
+	FEATURE_G1_TELEMATICS: {
+		"API_G2_LOCATE_UPDATE": "API_G1_LOCATE_UPDATE",
+	},
+}
+
+// generationFallbacks lists, per generation, the service path generations to
+// try in order. G3 vehicles are served by G2 endpoints where the backend has
+// no G3 counterpart yet.
+var generationFallbacks = map[string][]string{
+	FEATURE_G1_TELEMATICS: {FEATURE_G1_TELEMATICS},
+	FEATURE_G2_TELEMATICS: {FEATURE_G2_TELEMATICS},
+	FEATURE_G3_TELEMATICS: {FEATURE_G3_TELEMATICS, FEATURE_G2_TELEMATICS},
+}
+
+// serviceGenRe matches the generation segment of a service path.
+var serviceGenRe = regexp.MustCompile(`/service/(api_gen|g[0-9])/`)
+
+// Supports reports whether the vehicle's telematics generation offers c.
+// Vehicles of unknown generation are assumed to support everything.
+func (v *Vehicle) Supports(c Capability) bool {
+	if v.getAPIGen() == FEATURE_G1_TELEMATICS {
+		return slices.Contains(g1Capabilities, c)
+	}
+	return true
+}
+
+// requireCapability returns an UnsupportedFeatureError when the vehicle's
+// telematics generation lacks c.
+func (v *Vehicle) requireCapability(c Capability) error {
+	if v.Supports(c) {
+		return nil
+	}
+	return UnsupportedFeatureError{Feature: string(c), Message: "not available with " + v.getAPIGen() + " telematics"}
+}
+
+// commandURLs returns the command and status polling URLs of a c command for
+// the vehicle's generation. key is the apiURLs key of the command; its
+// "api_gen" segment is replaced with the generation.
+func (v *Vehicle) commandURLs(c Capability, key string) (reqUrl, pollingUrl string, err error) {
+	if err := v.requireCapability(c); err != nil {
+		return "", "", err
+	}
+	gen := v.getAPIGen()
+	if override, ok := endpointOverrides[gen][key]; ok {
+		key = override
+	}
+	reqUrl = MOBILE_API_VERSION + genPath(apiURLs[key], gen)
+	return reqUrl, v.statusURL(c), nil
+}
+
+// statusURL returns the URL c commands are polled on for the vehicle's
+// generation. Vehicles other than G1 use the G2 status table.
+func (v *Vehicle) statusURL(c Capability) string {
+	gen := v.getAPIGen()
+	table := FEATURE_G2_TELEMATICS
+	if gen == FEATURE_G1_TELEMATICS {
+		table = FEATURE_G1_TELEMATICS
+	}
+	key, ok := statusEndpoints[table][c]
+	if !ok {
+		key = "API_REMOTE_SVC_STATUS"
+	}
+	return MOBILE_API_VERSION + genPath(apiURLs[key], gen)
+}
+
+// endpointURL returns the URL of the apiURLs key for the vehicle's generation.
+func (v *Vehicle) endpointURL(key string) string {
+	return MOBILE_API_VERSION + genPath(apiURLs[key], v.getAPIGen())
+}
+
+// genPath returns path with its service generation segment set for gen. The
+// "api_gen" placeholder takes the generation (G2 when unknown); G3 vehicles
+// also get G3 in place of G2-only paths, which execute falls back from.
+func genPath(path, gen string) string {
+	switch gen {
+	case FEATURE_G1_TELEMATICS:
+		return strings.Replace(path, "/service/api_gen/", "/service/g1/", 1)
+	case FEATURE_G3_TELEMATICS:
+		return serviceGenRe.ReplaceAllString(path, "/service/g3/")
+	default:
+		return strings.Replace(path, "/service/api_gen/", "/service/g2/", 1)
+	}
+}
+
+// fallbackURLs returns url followed by the URLs of the generations it falls
+// back to, per generationFallbacks.
+func fallbackURLs(url string) []string {
+	m := serviceGenRe.FindStringSubmatch(url)
+	if m == nil {
+		return []string{url}
+	}
+	gens := generationFallbacks[m[1]]
+	if len(gens) == 0 {
+		return []string{url}
+	}
+	urls := make([]string, 0, len(gens))
+	for _, gen := range gens {
+		urls = append(urls, strings.Replace(url, m[0], "/service/"+gen+"/", 1))
+	}
+	return urls
+}
+
+// hasGenerationFallback reports whether url has another generation to fall
+// back to, so a 404 on it means the endpoint is missing rather than a retired
+// API version.
+func hasGenerationFallback(url string) bool {
+	return len(fallbackURLs(url)) > 1
+}
+
+// execute runs a request for the vehicle, falling back through older
+// generations' endpoints when the backend reports the endpoint missing
+// (ErrEndpointNotFound). Endpoints found missing are remembered so later
+// requests go straight to the fallback.
+func (v *Vehicle) execute(ctx context.Context, method, url string, params map[string]string, j bool) (*Response, error) {
+	urls := fallbackURLs(url)
+	var err error
+	for i, u := range urls {
+		last := i == len(urls)-1
+		if _, missing := v.missingEndpoints.Load(u); missing && !last {
+			continue
+		}
+		var resp *Response
+		resp, err = v.client.execute(ctx, method, u, params, j)
+		if last || !errors.Is(err, ErrEndpointNotFound) {
+			return resp, err
+		}
+		v.missingEndpoints.Store(u, true)
+		v.client.logger.Info("endpoint not available for vehicle generation; falling back",
+			"vin", v.Vin, "url", u, "fallback", urls[i+1])
+	}
+	return nil, err
+}
@@ -0,0 +1,184 @@
+package mysubaru
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+)
+
+// TestCommandURLs checks each generation gets its own command and status
+// endpoints.
+func TestCommandURLs(t *testing.T) {
+	tests := []struct {
+		gen        string
+		capability Capability
+		key        string
+		wantReq    string
+		wantPoll   string
+	}{
+		{FEATURE_G1_TELEMATICS, CapabilityLock, "API_LOCK", "/service/g1/lock/execute.json", apiURLs["API_REMOTE_SVC_STATUS"]},
+		{FEATURE_G2_TELEMATICS, CapabilityLock, "API_LOCK", "/service/g2/lock/execute.json", apiURLs["API_REMOTE_SVC_STATUS"]},
+		{FEATURE_G3_TELEMATICS, CapabilityLock, "API_LOCK", "/service/g3/lock/execute.json", "/service/g3/remoteService/status.json"},
+		{FEATURE_G3_TELEMATICS, CapabilitySendPOI, "API_G2_SEND_POI", "/service/g3/sendPoi/execute.json", "/service/g3/sendPoi/status.json"},
+		{FEATURE_G1_TELEMATICS, CapabilityHornLights, "API_HORN_LIGHTS", "/service/g1/hornLights/execute.json", apiURLs["API_G1_HORN_LIGHTS_STATUS"]},
+		{FEATURE_G2_TELEMATICS, CapabilityHornLights, "API_HORN_LIGHTS", "/service/g2/hornLights/execute.json", apiURLs["API_REMOTE_SVC_STATUS"]},
+		{FEATURE_G1_TELEMATICS, CapabilityLocate, "API_G2_LOCATE_UPDATE", apiURLs["API_G1_LOCATE_UPDATE"], apiURLs["API_G1_LOCATE_STATUS"]},
+		{FEATURE_G2_TELEMATICS, CapabilityLocate, "API_G2_LOCATE_UPDATE", apiURLs["API_G2_LOCATE_UPDATE"], apiURLs["API_G2_LOCATE_STATUS"]},
+		{FEATURE_G2_TELEMATICS, CapabilitySendPOI, "API_G2_SEND_POI", apiURLs["API_G2_SEND_POI"], apiURLs["API_G2_SEND_POI_STATUS"]},
+	}
+	for _, tt := range tests {
+		v := &Vehicle{Features: []string{tt.gen}}
+		reqUrl, pollingUrl, err := v.commandURLs(tt.capability, tt.key)
+		if err != nil {
+			t.Fatalf("%s %s: expected no error, got %v", tt.gen, tt.capability, err)
+		}
+		if reqUrl != MOBILE_API_VERSION+tt.wantReq {
+			t.Errorf("%s %s: expected command %s, got %s", tt.gen, tt.capability, tt.wantReq, reqUrl)
+		}
+		if pollingUrl != MOBILE_API_VERSION+tt.wantPoll {
+			t.Errorf("%s %s: expected status %s, got %s", tt.gen, tt.capability, tt.wantPoll, pollingUrl)
+		}
+	}
+}
+
+// TestG1UnsupportedCommands verifies commands G1 lacks fail with a typed
+// error before any request is made.
+func TestG1UnsupportedCommands(t *testing.T) {
+	v := &Vehicle{
+		Features:             []string{FEATURE_G1_TELEMATICS},
+		SubscriptionFeatures: []string{FEATURE_REMOTE, FEATURE_SAFETY},
+		client:               &Client{logger: slog.New(slog.NewTextHandler(io.Discard, nil))},
+	}
+	ctx := context.Background()
+
+	commands := map[string]func() error{
+		"EngineStart":    func() error { _, err := v.EngineStart(ctx, 10, 0, false); return err },
+		"EngineStop":     func() error { _, err := v.EngineStop(ctx); return err },
+		"ValetModeStart": func() error { _, err := v.ValetModeStart(ctx); return err },
+		"GetValetSetup":  func() error { _, err := v.GetValetSetup(ctx); return err },
+		"TripLogStart":   func() error { _, err := v.TripLogStart(ctx); return err },
+		"SendPOI":        func() error { _, err := v.SendPOI(ctx, POI{Name: "Home"}); return err },
+		"RefreshStatus":  func() error { return v.RefreshStatus(ctx) },
+	}
+	for name, run := range commands {
+		var featErr UnsupportedFeatureError
+		if err := run(); !errors.As(err, &featErr) {
+			t.Errorf("%s: expected UnsupportedFeatureError, got %v", name, err)
+		}
+	}
+
+	if !v.Supports(CapabilityLock) || !v.Supports(CapabilityHornLights) || !v.Supports(CapabilityLocate) {
+		t.Error("expected G1 to support lock, horn and lights, and locate")
+	}
+}
+
+// TestServiceStatusSXMError verifies an SXM error reported while polling is
+// mapped to its typed error.
+func TestServiceStatusSXMError(t *testing.T) {
+	v := &Vehicle{client: &Client{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}}
+	resp := &Response{
+		Success:  true,
+		DataName: "remoteServiceStatus",
+		Data:     []byte(`{"serviceRequestId":"1HGCM82633A004352_1751747301812_19_@NGTP","success":false,"cancelled":false,"remoteServiceType":"lock","remoteServiceState":"finished","errorCode":"SXM40006","result":null}`),
+	}
+	ch := make(chan string, 1)
+
+	err := v.handleServiceResponse(context.Background(), resp, "", "", ch, 1)
+	if !errors.Is(err, ErrInvalidPIN) {
+		t.Fatalf("expected ErrInvalidPIN, got %v", err)
+	}
+	if state := <-ch; state != "error" {
+		t.Errorf("expected error state, got %q", state)
+	}
+}
+
+// TestFallbackURLs checks G3 service paths fall back to G2 and other paths
+// are left alone.
+func TestFallbackURLs(t *testing.T) {
+	tests := []struct {
+		url  string
+		want []string
+	}{
+		{"/g2v33/service/g3/lock/execute.json", []string{"/g2v33/service/g3/lock/execute.json", "/g2v33/service/g2/lock/execute.json"}},
+		{"/g2v33/service/g2/lock/execute.json", []string{"/g2v33/service/g2/lock/execute.json"}},
+		{"/g2v33/service/g1/lock/execute.json", []string{"/g2v33/service/g1/lock/execute.json"}},
+		{"/g2v33/validateSession.json", []string{"/g2v33/validateSession.json"}},
+	}
+	for _, tt := range tests {
+		if got := fallbackURLs(tt.url); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.url, tt.want, got)
+		}
+	}
+}
+
+// TestG3EndpointFallback verifies a G3 endpoint the backend lacks falls back
+// to G2 without bumping the API version, and is skipped afterwards.
+func TestG3EndpointFallback(t *testing.T) {
+	g3Fetch := genPath(apiURLs["API_G2_GEOFENCE_FETCH"], FEATURE_G3_TELEMATICS)
+	routes := append([]endpointRoute{
+		{Method: http.MethodGet, Path: g3Fetch, Status: http.StatusNotFound, Response: `{"success":false,"errorCode":"404-soa-unableToParseResponseBody","dataName":null,"data":null}`},
+		{Method: http.MethodGet, Path: apiURLs["API_G2_GEOFENCE_FETCH"], Response: `{"success":true,"errorCode":null,"dataName":null,"data":[{"fenceId":"1","name":"Home","latitude":40.7128,"longitude":-74.006,"radius":500,"enabled":true}]}`},
+	}, standardTestRoutes()...)
+	ts := mockServerWithRoutes(t, routes)
+	ts.Start()
+	defer ts.Close()
+
+	msc, err := New(mockConfig(t))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ok, _, err := msc.Authenticate(context.Background()); !ok || err != nil {
+		t.Fatalf("expected authentication to succeed, got ok=%v, err=%v", ok, err)
+	}
+	v, err := msc.GetVehicleByVin(context.Background(), "1HGCM82633A004352")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gen := v.getAPIGen(); gen != FEATURE_G3_TELEMATICS {
+		t.Fatalf("expected a G3 test vehicle, got %s", gen)
+	}
+	v.SubscriptionFeatures = append(v.SubscriptionFeatures, FEATURE_SAFETY)
+	version := msc.getAPIVersion()
+
+	for range 2 {
+		fences, err := v.GetGeoFences(context.Background())
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(fences) != 1 || fences[0].Name != "Home" {
+			t.Fatalf("expected the G2 fence Home, got %+v", fences)
+		}
+	}
+	if got := msc.getAPIVersion(); got != version {
+		t.Errorf("expected API version %s, got %s", version, got)
+	}
+	if _, ok := v.missingEndpoints.Load(MOBILE_API_VERSION + g3Fetch); !ok {
+		t.Error("expected the G3 endpoint to be remembered as missing")
+	}
+}
+
+// TestG3EndpointSOAError verifies an SOA parse error from a G3 endpoint that
+// answers with HTTP 200 is returned as is, without falling back to G2 or
+// remembering the endpoint as missing.
+func TestG3EndpointSOAError(t *testing.T) {
+	g3Fetch := genPath(apiURLs["API_G2_GEOFENCE_FETCH"], FEATURE_G3_TELEMATICS)
+	v, _ := setupCountingVehicle(t, false,
+		endpointRoute{Method: http.MethodGet, Path: g3Fetch, Response: `{"success":false,"errorCode":"404-soa-unableToParseResponseBody","dataName":null,"data":null}`},
+		endpointRoute{Method: http.MethodGet, Path: apiURLs["API_G2_GEOFENCE_FETCH"], Response: `{"success":true,"errorCode":null,"dataName":null,"data":[{"fenceId":"1","name":"Home","latitude":40.7128,"longitude":-74.006,"radius":500,"enabled":true}]}`},
+	)
+
+	// The code is retryable; don't wait out the backoff.
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := v.GetGeoFences(ctx); err == nil || errors.Is(err, ErrEndpointNotFound) {
+		t.Fatalf("expected the SOA error, got %v", err)
+	}
+	if _, ok := v.missingEndpoints.Load(MOBILE_API_VERSION + g3Fetch); ok {
+		t.Error("expected the G3 endpoint not to be remembered as missing")
+	}
+}
@@ -0,0 +1,452 @@
+package mysubaru
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// GeoFenceStatus is the vehicle's position relative to a geofence.
+type GeoFenceStatus string
+
+const (
+	GeoFenceUnknown GeoFenceStatus = ""
+	GeoFenceInside  GeoFenceStatus = "INSIDE"
+	GeoFenceOutside GeoFenceStatus = "OUTSIDE"
+)
+
+// GeoFence is a boundary alert configured for the vehicle. Status is filled in
+// from API_G2_GEOFENCE_STATUS and is not sent when saving.
+type GeoFence struct {
+	ID           string         `json:"fenceId,omitempty"`
+	Name         string         `json:"name,omitempty"`
+	Latitude     float64        `json:"latitude"`
+	Longitude    float64        `json:"longitude"`
+	Radius       int            `json:"radius"` // in meters
+	Enabled      bool           `json:"enabled"`
+	AlertOnEntry bool           `json:"alertOnEntry"`
+	AlertOnExit  bool           `json:"alertOnExit"`
+	Status       GeoFenceStatus `json:"status,omitempty"`
+}
+
+// UnmarshalJSON accepts the field spellings the fetch endpoint uses across
+// app versions (id/geoFenceId, notifyOnEntry/notifyOnExit) and converts a
+// radius given in MILES or KM to meters.
+func (f *GeoFence) UnmarshalJSON(data []byte) error {
+	type plain GeoFence
+	var aux struct {
+		plain
+		AltID         string  `json:"id"`
+		GeoFenceID    string  `json:"geoFenceId"`
+		RadiusF       float64 `json:"radius"`
+		RadiusUnit    string  `json:"radiusUnit"`
+		NotifyOnEntry *bool   `json:"notifyOnEntry"`
+		NotifyOnExit  *bool   `json:"notifyOnExit"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*f = GeoFence(aux.plain)
+	if f.ID == "" {
+		f.ID = cmp.Or(aux.GeoFenceID, aux.AltID)
+	}
+	switch strings.ToUpper(aux.RadiusUnit) {
+	case "MILES", "MI":
+		f.Radius = int(math.Round(aux.RadiusF * 1609.344))
+	case "KM", "KILOMETERS":
+		f.Radius = int(math.Round(aux.RadiusF * 1000))
+	default:
+		f.Radius = int(math.Round(aux.RadiusF))
+	}
+	if aux.NotifyOnEntry != nil {
+		f.AlertOnEntry = *aux.NotifyOnEntry
+	}
+	if aux.NotifyOnExit != nil {
+		f.AlertOnExit = *aux.NotifyOnExit
+	}
+	f.Status = GeoFenceStatus(strings.ToUpper(string(f.Status)))
+	return nil
+}
+
+// Validate checks the fence's name, center and radius.
+func (f GeoFence) Validate() error {
+	if f.Name == "" {
+		return errors.New("geofence name cannot be empty")
+	}
+	if err := ValidateCoordinates(f.Latitude, f.Longitude); err != nil {
+		return fmt.Errorf("invalid coordinates: %w", err)
+	}
+	if err := ValidateGeoFenceRadius(f.Radius); err != nil {
+		return fmt.Errorf("invalid radius: %w", err)
+	}
+	return nil
+}
+
+// GeoFenceSettings represents a boundary alert configuration
+type GeoFenceSettings struct {
+	Enabled      bool    `json:"enabled"`
+	Name         string  `json:"name,omitempty"`
+	Latitude     float64 `json:"latitude"`
+	Longitude    float64 `json:"longitude"`
+	Radius       int     `json:"radius"` // in meters
+	AlertOnExit  bool    `json:"alertOnExit"`
+	AlertOnEntry bool    `json:"alertOnEntry"`
+}
+
+// checkSafetyFeature reports whether the vehicle can use the named Safety Plus
+// alert (geofence, speed fence, curfew): they need G2 or G3 telematics and a
+// Safety Plus subscription.
+func (v *Vehicle) checkSafetyFeature(name string) error {
+	if gen := v.getAPIGen(); gen != FEATURE_G2_TELEMATICS && gen != FEATURE_G3_TELEMATICS {
+		return UnsupportedFeatureError{Feature: FEATURE_G2_TELEMATICS, Message: name + " feature requires G2 or newer telematics"}
+	}
+	if !slices.Contains(v.SubscriptionFeatures, FEATURE_SAFETY) {
+		return fmt.Errorf("%s feature requires Safety Plus subscription: %w", name, ErrSubscriptionRequired)
+	}
+	return nil
+}
+
+// GetGeoFences retrieves all geofences configured for the vehicle, with their
+// current inside/outside status. When the status can't be fetched the fences
+// are returned with GeoFenceUnknown status.
+func (v *Vehicle) GetGeoFences(ctx context.Context) ([]GeoFence, error) {
+	if err := v.checkSafetyFeature("geofence"); err != nil {
+		return nil, err
+	}
+
+	var raw json.RawMessage
+	if err := v.fetchInto(ctx, GET, "API_G2_GEOFENCE_FETCH", map[string]string{}, false, &raw); err != nil {
+		return nil, err
+	}
+	fences, err := parseGeoFences(raw)
+	if err != nil {
+		v.client.logger.Error("error parsing geofences", "error", err.Error())
+		return nil, err
+	}
+
+	statuses, err := v.GetGeoFenceStatus(ctx)
+	if err != nil {
+		v.client.logger.Warn("couldn't fetch geofence status", "vin", v.Vin, "error", err.Error())
+		return fences, nil
+	}
+	for i := range fences {
+		if s, ok := statuses[fences[i].ID]; ok {
+			fences[i].Status = s
+		}
+	}
+	return fences, nil
+}
+
+// GetGeoFence retrieves the geofence with the given ID.
+func (v *Vehicle) GetGeoFence(ctx context.Context, fenceId string) (*GeoFence, error) {
+	fences, err := v.GetGeoFences(ctx)
+	if err != nil {
+		return nil, err
+	}
+	i := slices.IndexFunc(fences, func(f GeoFence) bool { return f.ID == fenceId })
+	if i < 0 {
+		return nil, fmt.Errorf("geofence %q not found", fenceId)
+	}
+	return &fences[i], nil
+}
+
+// GetGeoFenceStatus retrieves whether the vehicle is inside or outside each of
+// its geofences, keyed by fence ID.
+func (v *Vehicle) GetGeoFenceStatus(ctx context.Context) (map[string]GeoFenceStatus, error) {
+	if err := v.checkSafetyFeature("geofence"); err != nil {
+		return nil, err
+	}
+
+	var raw json.RawMessage
+	if err := v.fetchInto(ctx, GET, "API_G2_GEOFENCE_STATUS", map[string]string{}, false, &raw); err != nil {
+		return nil, err
+	}
+	statuses, err := parseGeoFenceStatus(raw)
+	if err != nil {
+		v.client.logger.Error("error parsing geofence status", "error", err.Error())
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// CreateGeoFence adds a new geofence to the vehicle. The ID is assigned by
+// MySubaru; use GetGeoFences to read it back.
+func (v *Vehicle) CreateGeoFence(ctx context.Context, fence GeoFence) (chan string, error) {
+	if err := v.checkSafetyFeature("geofence"); err != nil {
+		return nil, err
+	}
+	if err := fence.Validate(); err != nil {
+		return nil, err
+	}
+
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := v.geoFenceParams(fence, pin)
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityGeoFence, "API_G2_GEOFENCE")
+	if err != nil {
+		return nil, err
+	}
+
+	return v.actuate(ctx, params, reqUrl, pollingUrl)
+}
+
+// ReplaceGeoFence replaces the geofence with the same ID.
+func (v *Vehicle) ReplaceGeoFence(ctx context.Context, fence GeoFence) (chan string, error) {
+	if err := v.checkSafetyFeature("geofence"); err != nil {
+		return nil, err
+	}
+	if fence.ID == "" {
+		return nil, errors.New("geofence ID cannot be empty")
+	}
+	if err := fence.Validate(); err != nil {
+		return nil, err
+	}
+
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := v.geoFenceParams(fence, pin)
+	params["fenceId"] = fence.ID
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityGeoFence, "API_G2_GEOFENCE")
+	if err != nil {
+		return nil, err
+	}
+
+	return v.actuate(ctx, params, reqUrl, pollingUrl)
+}
+
+// UpdateGeoFence updates an existing geofence. Zero coordinates, a zero
+// radius and an empty name keep the fence's current values.
+//
+// Deprecated: use ReplaceGeoFence.
+func (v *Vehicle) UpdateGeoFence(ctx context.Context, fenceId string, latitude, longitude float64, radius int, name string, enabled, entryAlert, exitAlert bool) (chan string, error) {
+	if fenceId == "" {
+		return nil, errors.New("geofence ID cannot be empty")
+	}
+	fence, err := v.GetGeoFence(ctx, fenceId)
+	if err != nil {
+		return nil, err
+	}
+	if latitude != 0 {
+		fence.Latitude = latitude
+	}
+	if longitude != 0 {
+		fence.Longitude = longitude
+	}
+	if radius != 0 {
+		fence.Radius = radius
+	}
+	if name != "" {
+		fence.Name = name
+	}
+	fence.Enabled, fence.AlertOnEntry, fence.AlertOnExit = enabled, entryAlert, exitAlert
+	return v.ReplaceGeoFence(ctx, *fence)
+}
+
+// SetGeoFence sets up a geofence for the vehicle with specified parameters.
+//
+// Deprecated: use CreateGeoFence.
+func (v *Vehicle) SetGeoFence(ctx context.Context, latitude, longitude float64, radius int, name string, enabled, entryAlert, exitAlert bool) (chan string, error) {
+	return v.CreateGeoFence(ctx, GeoFence{
+		Name:         name,
+		Latitude:     latitude,
+		Longitude:    longitude,
+		Radius:       radius,
+		Enabled:      enabled,
+		AlertOnEntry: entryAlert,
+		AlertOnExit:  exitAlert,
+	})
+}
+
+// DeleteGeoFence removes a geofence from the vehicle.
+func (v *Vehicle) DeleteGeoFence(ctx context.Context, fenceId string) (chan string, error) {
+	if err := v.checkSafetyFeature("geofence"); err != nil {
+		return nil, err
+	}
+	if fenceId == "" {
+		return nil, errors.New("geofence ID cannot be empty")
+	}
+
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		"delay":   "0",
+		"vin":     v.Vin,
+		"pin":     pin,
+		"fenceId": fenceId,
+		"delete":  "true",
+	}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityGeoFence, "API_G2_GEOFENCE")
+	if err != nil {
+		return nil, err
+	}
+
+	return v.actuate(ctx, params, reqUrl, pollingUrl)
+}
+
+// GetGeoFenceSettings retrieves the current geo-fence (boundary alert) settings.
+// On vehicles with several fences it returns the first one.
+func (v *Vehicle) GetGeoFenceSettings(ctx context.Context) (*GeoFenceSettings, error) {
+	if err := v.checkSafetyFeature("geofence"); err != nil {
+		return nil, err
+	}
+	var raw json.RawMessage
+	if err := v.fetchInto(ctx, GET, "API_G2_GEOFENCE_FETCH", map[string]string{}, false, &raw); err != nil {
+		return nil, err
+	}
+	fences, err := parseGeoFences(raw)
+	if err != nil {
+		v.client.logger.Error("error parsing geofences", "error", err.Error())
+		return nil, err
+	}
+	if len(fences) == 0 {
+		return &GeoFenceSettings{}, nil
+	}
+	f := fences[0]
+	return &GeoFenceSettings{
+		Enabled:      f.Enabled,
+		Name:         f.Name,
+		Latitude:     f.Latitude,
+		Longitude:    f.Longitude,
+		Radius:       f.Radius,
+		AlertOnExit:  f.AlertOnExit,
+		AlertOnEntry: f.AlertOnEntry,
+	}, nil
+}
+
+// SaveGeoFenceSettings saves geo-fence (boundary alert) settings
+func (v *Vehicle) SaveGeoFenceSettings(ctx context.Context, settings GeoFenceSettings) error {
+	if err := v.checkSafetyFeature("geofence"); err != nil {
+		return err
+	}
+	params := map[string]string{
+		"vin":          v.Vin,
+		"latitude":     fmt.Sprintf("%f", settings.Latitude),
+		"longitude":    fmt.Sprintf("%f", settings.Longitude),
+		"radius":       strconv.Itoa(settings.Radius),
+		"alertOnExit":  strconv.FormatBool(settings.AlertOnExit),
+		"alertOnEntry": strconv.FormatBool(settings.AlertOnEntry),
+		"enabled":      strconv.FormatBool(settings.Enabled),
+	}
+	if settings.Name != "" {
+		params["name"] = settings.Name
+	}
+	return v.fetchInto(ctx, POST, "API_G2_GEOFENCE_SAVE", params, true, nil)
+}
+
+// ActivateGeoFence activates the geo-fence alert on the vehicle
+func (v *Vehicle) ActivateGeoFence(ctx context.Context) (chan string, error) {
+	return v.switchGeoFence(ctx, "activate")
+}
+
+// DeactivateGeoFence deactivates the geo-fence alert on the vehicle
+func (v *Vehicle) DeactivateGeoFence(ctx context.Context) (chan string, error) {
+	return v.switchGeoFence(ctx, "deactivate")
+}
+
+func (v *Vehicle) switchGeoFence(ctx context.Context, action string) (chan string, error) {
+	if err := v.checkSafetyFeature("geofence"); err != nil {
+		return nil, err
+	}
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		"vin":    v.Vin,
+		"pin":    pin,
+		"action": action,
+	}
+	reqUrl := v.endpointURL("API_G2_GEOFENCE")
+	pollingUrl := v.endpointURL("API_G2_GEOFENCE_STATUS")
+
+	return v.actuate(ctx, params, reqUrl, pollingUrl)
+}
+
+// geoFenceParams returns the request parameters describing fence, sent with
+// pin.
+func (v *Vehicle) geoFenceParams(fence GeoFence, pin string) map[string]string {
+	return map[string]string{
+		"delay":      "0",
+		"vin":        v.Vin,
+		"pin":        pin,
+		"latitude":   fmt.Sprintf("%.6f", fence.Latitude),
+		"longitude":  fmt.Sprintf("%.6f", fence.Longitude),
+		"radius":     strconv.Itoa(fence.Radius),
+		"name":       fence.Name,
+		"enabled":    strconv.FormatBool(fence.Enabled),
+		"entryAlert": strconv.FormatBool(fence.AlertOnEntry),
+		"exitAlert":  strconv.FormatBool(fence.AlertOnExit),
+	}
+}
+
+// parseGeoFences decodes the fetch response, which is a list of fences, an
+// object wrapping that list, a single fence, or a status string (or null) when
+// no fence is configured.
+func parseGeoFences(raw json.RawMessage) ([]GeoFence, error) {
+	if isJSONStringOrNull(raw) {
+		return nil, nil
+	}
+	var fences []GeoFence
+	if err := json.Unmarshal(raw, &fences); err == nil {
+		return fences, nil
+	}
+	var wrapped struct {
+		GeoFences []GeoFence `json:"geoFences"`
+	}
+	if err := json.Unmarshal(raw, &wrapped); err == nil && wrapped.GeoFences != nil {
+		return wrapped.GeoFences, nil
+	}
+	var fence GeoFence
+	if err := json.Unmarshal(raw, &fence); err != nil {
+		return nil, err
+	}
+	return []GeoFence{fence}, nil
+}
+
+// parseGeoFenceStatus decodes the status response, a list of
+// {"fenceId", "status"} entries (status INSIDE or OUTSIDE, or an "inside"
+// bool), optionally wrapped in an object.
+func parseGeoFenceStatus(raw json.RawMessage) (map[string]GeoFenceStatus, error) {
+	type entry struct {
+		ID     string `json:"fenceId"`
+		Status string `json:"status"`
+		Inside *bool  `json:"inside"`
+	}
+	statuses := map[string]GeoFenceStatus{}
+	if isJSONStringOrNull(raw) {
+		return statuses, nil
+	}
+	var entries []entry
+	if err := json.Unmarshal(raw, &entries); err != nil {
+		var wrapped struct {
+			GeoFences []entry `json:"geoFences"`
+		}
+		if err := json.Unmarshal(raw, &wrapped); err != nil {
+			return nil, err
+		}
+		entries = wrapped.GeoFences
+	}
+	for _, e := range entries {
+		switch {
+		case e.Inside != nil && *e.Inside:
+			statuses[e.ID] = GeoFenceInside
+		case e.Inside != nil:
+			statuses[e.ID] = GeoFenceOutside
+		default:
+			statuses[e.ID] = GeoFenceStatus(strings.ToUpper(e.Status))
+		}
+	}
+	return statuses, nil
+}
@@ -0,0 +1,120 @@
+package mysubaru
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// TestParseGeoFences covers the response shapes of the fetch endpoint and the
+// field spellings GeoFence accepts.
+func TestParseGeoFences(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want []GeoFence
+	}{
+		{"not configured", `"NOT_CONFIGURED"`, nil},
+		{"list", `[{"fenceId":"1","name":"Home","latitude":40.7,"longitude":-74,"radius":500,"enabled":true,"alertOnExit":true}]`,
+			[]GeoFence{{ID: "1", Name: "Home", Latitude: 40.7, Longitude: -74, Radius: 500, Enabled: true, AlertOnExit: true}}},
+		{"wrapped", `{"geoFences":[{"id":"7","name":"Work","radius":1000},{"geoFenceId":"8","name":"Gym","radius":200}]}`,
+			[]GeoFence{{ID: "7", Name: "Work", Radius: 1000}, {ID: "8", Name: "Gym", Radius: 200}}},
+		{"single in miles", `{"name":"Home","radius":5,"radiusUnit":"MILES","notifyOnEntry":true,"notifyOnExit":false}`,
+			[]GeoFence{{Name: "Home", Radius: 8047, AlertOnEntry: true}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGeoFences(json.RawMessage(tt.raw))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d fences, got %+v", len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("expected %+v, got %+v", tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
+
+// TestGeoFenceGate verifies every geofence method is refused without G2
+// telematics or the Safety Plus subscription.
+func TestGeoFenceGate(t *testing.T) {
+	g1 := &Vehicle{Features: []string{FEATURE_G1_TELEMATICS}, SubscriptionFeatures: []string{FEATURE_SAFETY}}
+	if _, err := g1.GetGeoFences(context.Background()); !IsUnsupportedFeatureError(err) {
+		t.Errorf("expected UnsupportedFeatureError, got %v", err)
+	}
+	noSafety := &Vehicle{Features: []string{FEATURE_G2_TELEMATICS}, SubscriptionFeatures: []string{FEATURE_REMOTE}}
+	if _, err := noSafety.DeleteGeoFence(context.Background(), "1"); !errors.Is(err, ErrSubscriptionRequired) {
+		t.Errorf("expected ErrSubscriptionRequired, got %v", err)
+	}
+	if _, err := noSafety.GetGeoFenceSettings(context.Background()); !errors.Is(err, ErrSubscriptionRequired) {
+		t.Errorf("expected ErrSubscriptionRequired, got %v", err)
+	}
+}
+
+// TestGetGeoFences fetches several fences and merges their status.
+func TestGetGeoFences(t *testing.T) {
+	routes := append(standardTestRoutes(),
+		endpointRoute{Method: http.MethodGet, Path: apiURLs["API_G2_GEOFENCE_FETCH"], Response: `{"success":true,"errorCode":null,"dataName":null,"data":[{"fenceId":"1","name":"Home","latitude":40.7128,"longitude":-74.006,"radius":500,"enabled":true},{"fenceId":"2","name":"School","latitude":40.73,"longitude":-73.99,"radius":300,"enabled":true}]}`},
+		endpointRoute{Method: http.MethodGet, Path: apiURLs["API_G2_GEOFENCE_STATUS"], Response: `{"success":true,"errorCode":null,"dataName":null,"data":[{"fenceId":"1","status":"inside"},{"fenceId":"2","inside":false}]}`},
+	)
+	ts := mockServerWithRoutes(t, routes)
+	ts.Start()
+	defer ts.Close()
+
+	msc, err := New(mockConfig(t))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ok, _, err := msc.Authenticate(context.Background()); !ok || err != nil {
+		t.Fatalf("expected authentication to succeed, got ok=%v, err=%v", ok, err)
+	}
+	v, err := msc.GetVehicleByVin(context.Background(), "1HGCM82633A004352")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	v.Features = append(v.Features, FEATURE_G2_TELEMATICS)
+	v.SubscriptionFeatures = append(v.SubscriptionFeatures, FEATURE_SAFETY)
+
+	fences, err := v.GetGeoFences(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(fences) != 2 || fences[0].Status != GeoFenceInside || fences[1].Status != GeoFenceOutside {
+		t.Fatalf("unexpected fences: %+v", fences)
+	}
+
+	f, err := v.GetGeoFence(context.Background(), "2")
+	if err != nil || f.Name != "School" {
+		t.Errorf("expected fence School, got %+v, %v", f, err)
+	}
+	if _, err := v.GetGeoFence(context.Background(), "9"); err == nil {
+		t.Error("expected error for unknown fence")
+	}
+
+	settings, err := v.GetGeoFenceSettings(context.Background())
+	if err != nil || settings.Name != "Home" || settings.Radius != 500 {
+		t.Errorf("expected the first fence's settings, got %+v, %v", settings, err)
+	}
+
+	ctx, log := WithDryRun(context.Background())
+	if _, err := v.UpdateGeoFence(ctx, "2", 0, 0, 400, "", true, true, false); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cmd, _ := log.Last(); cmd.Params["fenceId"] != "2" || cmd.Params["name"] != "School" || cmd.Params["radius"] != "400" || cmd.Params["latitude"] != "40.730000" {
+		t.Errorf("expected the update to keep the unset fields, got %+v", cmd.Params)
+	}
+
+	if _, err := v.ReplaceGeoFence(context.Background(), GeoFence{Name: "Home", Latitude: 40.7, Longitude: -74, Radius: 500}); err == nil {
+		t.Error("expected update without ID to be rejected")
+	}
+	if _, err := v.CreateGeoFence(context.Background(), GeoFence{Name: "Tiny", Latitude: 40.7, Longitude: -74, Radius: 10}); err == nil {
+		t.Error("expected too small radius to be rejected")
+	}
+}
@@ -0,0 +1,483 @@
+package mysubaru
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+)
+
+// earthRadiusMeters is the mean Earth radius used by DistanceBetween and Bearing.
+const earthRadiusMeters = 6371008.8
+
+const (
+	// DefaultFenceHysteresis is how far, in meters, a position must be past a
+	// fence boundary before the engine changes the inside/outside state.
+	DefaultFenceHysteresis = 50.0
+	// DefaultFencePollInterval is how often FenceEngine.Run polls the vehicle.
+	DefaultFencePollInterval = 5 * time.Minute
+)
+
+// LatLng is a WGS84 position in degrees.
+type LatLng struct {
+	Lat float64 `json:"lat"`
+	Lng float64 `json:"lng"`
+}
+
+// DistanceBetween returns the great-circle distance between a and b in meters.
+func DistanceBetween(a, b LatLng) float64 {
+	φ1, φ2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dφ := φ2 - φ1
+	dλ := (b.Lng - a.Lng) * math.Pi / 180
+	h := math.Sin(dφ/2)*math.Sin(dφ/2) + math.Cos(φ1)*math.Cos(φ2)*math.Sin(dλ/2)*math.Sin(dλ/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Bearing returns the initial bearing from a to b in degrees clockwise from
+// north, in [0, 360).
+func Bearing(a, b LatLng) float64 {
+	φ1, φ2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dλ := (b.Lng - a.Lng) * math.Pi / 180
+	y := math.Sin(dλ) * math.Cos(φ2)
+	x := math.Cos(φ1)*math.Sin(φ2) - math.Sin(φ1)*math.Cos(φ2)*math.Cos(dλ)
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+}
+
+// LocalFence is a geofence evaluated by FenceEngine. It is a circle around
+// Center when Polygon is empty, otherwise the polygon (the first and last
+// vertices need not repeat).
+type LocalFence struct {
+	ID      string
+	Name    string
+	Center  LatLng
+	Radius  float64 // in meters, circles only
+	Polygon []LatLng
+	Dwell   time.Duration // overrides FenceEngineOptions.Dwell when set
+}
+
+// Validate checks the fence has an ID and a usable shape.
+func (f LocalFence) Validate() error {
+	if f.ID == "" {
+		return errors.New("fence ID cannot be empty")
+	}
+	if len(f.Polygon) == 0 {
+		if err := ValidateCoordinates(f.Center.Lat, f.Center.Lng); err != nil {
+			return fmt.Errorf("fence %s: invalid center: %w", f.ID, err)
+		}
+		if f.Radius <= 0 {
+			return fmt.Errorf("fence %s: radius must be positive", f.ID)
+		}
+		return nil
+	}
+	if len(f.Polygon) < 3 {
+		return fmt.Errorf("fence %s: polygon needs at least 3 vertices", f.ID)
+	}
+	for _, p := range f.Polygon {
+		if err := ValidateCoordinates(p.Lat, p.Lng); err != nil {
+			return fmt.Errorf("fence %s: invalid vertex: %w", f.ID, err)
+		}
+	}
+	return nil
+}
+
+// Contains reports whether p is inside the fence.
+func (f LocalFence) Contains(p LatLng) bool {
+	return f.signedDistance(p) <= 0
+}
+
+// signedDistance returns the distance in meters from p to the fence boundary,
+// negative inside the fence.
+func (f LocalFence) signedDistance(p LatLng) float64 {
+	if len(f.Polygon) == 0 {
+		return DistanceBetween(f.Center, p) - f.Radius
+	}
+
+	// Project the vertices onto a plane tangent at p; fences are small enough
+	// for the equirectangular approximation.
+	kx := earthRadiusMeters * math.Pi / 180 * math.Cos(p.Lat*math.Pi/180)
+	ky := earthRadiusMeters * math.Pi / 180
+	n := len(f.Polygon)
+	inside := false
+	edge := math.Inf(1)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		ax, ay := (f.Polygon[j].Lng-p.Lng)*kx, (f.Polygon[j].Lat-p.Lat)*ky
+		bx, by := (f.Polygon[i].Lng-p.Lng)*kx, (f.Polygon[i].Lat-p.Lat)*ky
+		if (ay > 0) != (by > 0) && 0 < ax+(0-ay)*(bx-ax)/(by-ay) {
+			inside = !inside
+		}
+		edge = math.Min(edge, segmentDistance(ax, ay, bx, by))
+	}
+	if inside {
+		return -edge
+	}
+	return edge
+}
+
+// depth returns how far, in meters, the deepest point of the fence lies from
+// its boundary: the radius of a circle, and for a polygon the deepest of a
+// grid of points over its bounding box.
+func (f LocalFence) depth() float64 {
+	if len(f.Polygon) == 0 {
+		return f.Radius
+	}
+	const steps = 16
+	lo, hi := f.Polygon[0], f.Polygon[0]
+	for _, p := range f.Polygon[1:] {
+		lo.Lat, lo.Lng = math.Min(lo.Lat, p.Lat), math.Min(lo.Lng, p.Lng)
+		hi.Lat, hi.Lng = math.Max(hi.Lat, p.Lat), math.Max(hi.Lng, p.Lng)
+	}
+	depth := 0.0
+	for i := 1; i < steps; i++ {
+		for j := 1; j < steps; j++ {
+			p := LatLng{
+				Lat: lo.Lat + (hi.Lat-lo.Lat)*float64(i)/steps,
+				Lng: lo.Lng + (hi.Lng-lo.Lng)*float64(j)/steps,
+			}
+			depth = math.Max(depth, -f.signedDistance(p))
+		}
+	}
+	return depth
+}
+
+// segmentDistance returns the distance from the origin to segment a-b.
+func segmentDistance(ax, ay, bx, by float64) float64 {
+	dx, dy := bx-ax, by-ay
+	t := 0.0
+	if l := dx*dx + dy*dy; l > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/l))
+	}
+	return math.Hypot(ax+t*dx, ay+t*dy)
+}
+
+// ParseGeoJSONFences reads fences from a GeoJSON FeatureCollection, Feature or
+// bare geometry. Polygons use their outer ring; MultiPolygons become one fence
+// per polygon. A Point becomes a circle with the radius (meters) from the
+// feature's "radius" property. IDs come from the feature id or the "id"
+// property, falling back to the feature index; names from the "name" property.
+func ParseGeoJSONFences(data []byte) ([]LocalFence, error) {
+	var doc struct {
+		Type       string            `json:"type"`
+		Features   []json.RawMessage `json:"features"`
+		ID         any               `json:"id"`
+		Geometry   json.RawMessage   `json:"geometry"`
+		Properties map[string]any    `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+
+	switch doc.Type {
+	case "FeatureCollection":
+		var fences []LocalFence
+		for i, raw := range doc.Features {
+			fs, err := ParseGeoJSONFences(raw)
+			if err != nil {
+				return nil, fmt.Errorf("feature %d: %w", i, err)
+			}
+			for j := range fs {
+				switch {
+				case fs[j].ID != "":
+				case len(fs) > 1:
+					fs[j].ID = fmt.Sprintf("%d-%d", i, j)
+				default:
+					fs[j].ID = fmt.Sprint(i)
+				}
+			}
+			fences = append(fences, fs...)
+		}
+		return fences, nil
+	case "Feature":
+		fences, err := parseGeoJSONGeometry(doc.Geometry, doc.Properties)
+		if err != nil {
+			return nil, err
+		}
+		id := geoJSONString(doc.ID)
+		if id == "" {
+			id = geoJSONString(doc.Properties["id"])
+		}
+		name := geoJSONString(doc.Properties["name"])
+		for i := range fences {
+			fences[i].ID, fences[i].Name = id, name
+			if len(fences) > 1 && id != "" {
+				fences[i].ID = fmt.Sprintf("%s-%d", id, i)
+			}
+		}
+		return fences, nil
+	default:
+		return parseGeoJSONGeometry(data, nil)
+	}
+}
+
+// parseGeoJSONGeometry converts one GeoJSON geometry to fences.
+func parseGeoJSONGeometry(data []byte, props map[string]any) ([]LocalFence, error) {
+	var g struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates"`
+	}
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, err
+	}
+
+	ring := func(coords [][]float64) []LatLng {
+		pts := make([]LatLng, 0, len(coords))
+		for _, c := range coords {
+			if len(c) >= 2 {
+				pts = append(pts, LatLng{Lat: c[1], Lng: c[0]})
+			}
+		}
+		// GeoJSON rings repeat the first vertex at the end.
+		if n := len(pts); n > 1 && pts[0] == pts[n-1] {
+			pts = pts[:n-1]
+		}
+		return pts
+	}
+
+	switch g.Type {
+	case "Point":
+		var c []float64
+		if err := json.Unmarshal(g.Coordinates, &c); err != nil {
+			return nil, err
+		}
+		if len(c) < 2 {
+			return nil, errors.New("point needs longitude and latitude")
+		}
+		radius, _ := props["radius"].(float64)
+		if radius <= 0 {
+			return nil, errors.New(`point fence needs a positive "radius" property`)
+		}
+		return []LocalFence{{Center: LatLng{Lat: c[1], Lng: c[0]}, Radius: radius}}, nil
+	case "Polygon":
+		var rings [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &rings); err != nil {
+			return nil, err
+		}
+		if len(rings) == 0 {
+			return nil, errors.New("polygon has no rings")
+		}
+		return []LocalFence{{Polygon: ring(rings[0])}}, nil
+	case "MultiPolygon":
+		var polys [][][][]float64
+		if err := json.Unmarshal(g.Coordinates, &polys); err != nil {
+			return nil, err
+		}
+		var fences []LocalFence
+		for _, rings := range polys {
+			if len(rings) > 0 {
+				fences = append(fences, LocalFence{Polygon: ring(rings[0])})
+			}
+		}
+		return fences, nil
+	default:
+		return nil, fmt.Errorf("unsupported GeoJSON geometry %q", g.Type)
+	}
+}
+
+// geoJSONString renders a GeoJSON id or property (string or number) as a string.
+func geoJSONString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// FenceEventType is the kind of change a FenceEngine reports.
+type FenceEventType string
+
+const (
+	FenceEnter FenceEventType = "enter" // the vehicle arrived inside the fence
+	FenceExit  FenceEventType = "exit"  // the vehicle left the fence
+	FenceDwell FenceEventType = "dwell" // the vehicle stayed inside for the dwell time
+)
+
+// FenceEvent is a fence transition for one vehicle.
+type FenceEvent struct {
+	Type     FenceEventType
+	VIN      string
+	Fence    LocalFence
+	Position LatLng
+	Time     time.Time
+	Err      error // polling error from Run; Type is empty
+}
+
+// FenceEngineOptions configures a FenceEngine. Zero values use the defaults.
+type FenceEngineOptions struct {
+	Hysteresis   float64       // meters past the boundary to change state, default DefaultFenceHysteresis; capped at half a fence's depth
+	Dwell        time.Duration // time inside before a dwell event; zero disables dwell events
+	PollInterval time.Duration // Run poll interval, default DefaultFencePollInterval
+}
+
+// fenceState is a vehicle's tracked state for one fence.
+type fenceState struct {
+	inside  bool
+	since   time.Time // when inside last changed
+	dwelled bool      // dwell reported for the current stay
+}
+
+// FenceEngine evaluates vehicle positions against local fences, without the
+// Safety Plus subscription boundary alerts need. It is safe for concurrent use
+// and tracks each VIN separately.
+type FenceEngine struct {
+	opts FenceEngineOptions
+	now  func() time.Time
+
+	mu         sync.Mutex
+	fences     []LocalFence
+	hysteresis map[string]float64                // fence ID -> effective hysteresis
+	state      map[string]map[string]*fenceState // VIN -> fence ID -> state
+}
+
+// NewFenceEngine returns an engine evaluating the given fences.
+func NewFenceEngine(fences []LocalFence, opts FenceEngineOptions) (*FenceEngine, error) {
+	if opts.Hysteresis <= 0 {
+		opts.Hysteresis = DefaultFenceHysteresis
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultFencePollInterval
+	}
+	e := &FenceEngine{opts: opts, now: time.Now, hysteresis: map[string]float64{}, state: map[string]map[string]*fenceState{}}
+	for _, f := range fences {
+		if err := e.AddFence(f); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// AddFence adds a fence, replacing any fence with the same ID. Fences less
+// than twice the hysteresis deep use half their depth instead, so a position
+// can still get far enough inside to enter them.
+func (e *FenceEngine) AddFence(f LocalFence) error {
+	if err := f.Validate(); err != nil {
+		return err
+	}
+	h := math.Min(e.opts.Hysteresis, f.depth()/2)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.hysteresis[f.ID] = h
+	if i := slices.IndexFunc(e.fences, func(o LocalFence) bool { return o.ID == f.ID }); i >= 0 {
+		e.fences[i] = f
+		for _, fs := range e.state {
+			delete(fs, f.ID)
+		}
+		return nil
+	}
+	e.fences = append(e.fences, f)
+	return nil
+}
+
+// RemoveFence removes the fence with the given ID.
+func (e *FenceEngine) RemoveFence(id string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.fences = slices.DeleteFunc(e.fences, func(f LocalFence) bool { return f.ID == id })
+	delete(e.hysteresis, id)
+	for _, fs := range e.state {
+		delete(fs, id)
+	}
+}
+
+// Fences returns the fences, in the order they were added.
+func (e *FenceEngine) Fences() []LocalFence {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return slices.Clone(e.fences)
+}
+
+// Update evaluates a position of the vehicle vin at time at and returns the
+// resulting events. The first position seen for a fence only sets the state;
+// it never produces enter or exit events.
+func (e *FenceEngine) Update(vin string, pos LatLng, at time.Time) []FenceEvent {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	states := e.state[vin]
+	if states == nil {
+		states = map[string]*fenceState{}
+		e.state[vin] = states
+	}
+
+	var events []FenceEvent
+	event := func(t FenceEventType, f LocalFence) {
+		events = append(events, FenceEvent{Type: t, VIN: vin, Fence: f, Position: pos, Time: at})
+	}
+	for _, f := range e.fences {
+		d := f.signedDistance(pos)
+		st, ok := states[f.ID]
+		if !ok {
+			states[f.ID] = &fenceState{inside: d <= 0, since: at}
+			continue
+		}
+		h := e.hysteresis[f.ID]
+		switch {
+		case !st.inside && d < -h:
+			st.inside, st.since, st.dwelled = true, at, false
+			event(FenceEnter, f)
+		case st.inside && d > h:
+			st.inside, st.since = false, at
+			event(FenceExit, f)
+		}
+		dwell := cmp.Or(f.Dwell, e.opts.Dwell)
+		if st.inside && !st.dwelled && dwell > 0 && at.Sub(st.since) >= dwell {
+			st.dwelled = true
+			event(FenceDwell, f)
+		}
+	}
+	return events
+}
+
+// Observe evaluates the vehicle's last known GeoLocation. The location
+// timestamp is used when the API reported one.
+func (e *FenceEngine) Observe(v *Vehicle) []FenceEvent {
+	v.mu.RLock()
+	vin := v.Vin
+	loc := v.GeoLocation
+	v.mu.RUnlock()
+
+	if loc.Latitude == 0 && loc.Longitude == 0 {
+		return nil
+	}
+	at := loc.Updated.Time
+	if at.IsZero() {
+		at = e.now()
+	}
+	return e.Update(vin, LatLng{Lat: loc.Latitude, Lng: loc.Longitude}, at)
+}
+
+// Run polls GetVehicleStatus for each vehicle every PollInterval until ctx is
+// cancelled, and sends the fence events on the returned channel, which is
+// closed when Run stops.
+func (e *FenceEngine) Run(ctx context.Context, vehicles ...*Vehicle) <-chan FenceEvent {
+	ch := make(chan FenceEvent, 8)
+	go func() {
+		defer close(ch)
+		for {
+			for _, v := range vehicles {
+				var events []FenceEvent
+				if err := v.GetVehicleStatus(ctx); err != nil {
+					events = []FenceEvent{{VIN: v.Vin, Time: e.now(), Err: err}}
+				} else {
+					events = e.Observe(v)
+				}
+				for _, ev := range events {
+					select {
+					case ch <- ev:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+			if sleepCtx(ctx, e.opts.PollInterval) != nil {
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,138 @@
+package mysubaru
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestDistanceBearing checks the helpers against known values.
+func TestDistanceBearing(t *testing.T) {
+	nyc := LatLng{Lat: 40.7128, Lng: -74.0060}
+	london := LatLng{Lat: 51.5074, Lng: -0.1278}
+	if d := DistanceBetween(nyc, london); math.Abs(d-5570e3) > 5e3 {
+		t.Errorf("expected about 5570 km, got %.0f m", d)
+	}
+	if b := Bearing(nyc, london); math.Abs(b-51.2) > 0.5 {
+		t.Errorf("expected bearing about 51.2°, got %.1f", b)
+	}
+	if b := Bearing(LatLng{}, LatLng{Lat: -1}); b != 180 {
+		t.Errorf("expected due south, got %.1f", b)
+	}
+}
+
+// TestParseGeoJSONFences reads a polygon and a point fence and checks
+// containment.
+func TestParseGeoJSONFences(t *testing.T) {
+	doc := `{"type":"FeatureCollection","features":[
+		{"type":"Feature","id":"school","properties":{"name":"School"},"geometry":{"type":"Polygon","coordinates":[[[-74.01,40.71],[-74.00,40.71],[-74.00,40.72],[-74.01,40.72],[-74.01,40.71]]]}},
+		{"type":"Feature","properties":{"name":"Home","radius":200},"geometry":{"type":"Point","coordinates":[-73.98,40.75]}}
+	]}`
+	fences, err := ParseGeoJSONFences([]byte(doc))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(fences) != 2 || fences[0].ID != "school" || len(fences[0].Polygon) != 4 || fences[1].ID != "1" || fences[1].Radius != 200 {
+		t.Fatalf("unexpected fences: %+v", fences)
+	}
+	for _, f := range fences {
+		if err := f.Validate(); err != nil {
+			t.Errorf("expected fence %s to be valid, got %v", f.ID, err)
+		}
+	}
+
+	if !fences[0].Contains(LatLng{Lat: 40.715, Lng: -74.005}) || fences[0].Contains(LatLng{Lat: 40.725, Lng: -74.005}) {
+		t.Error("unexpected polygon containment")
+	}
+	// 100 m outside the south edge.
+	if d := fences[0].signedDistance(LatLng{Lat: 40.71 - 100/111195.0, Lng: -74.005}); math.Abs(d-100) > 1 {
+		t.Errorf("expected about 100 m, got %.1f", d)
+	}
+	if !fences[1].Contains(LatLng{Lat: 40.7505, Lng: -73.98}) {
+		t.Error("expected point inside the circle")
+	}
+
+	if _, err := ParseGeoJSONFences([]byte(`{"type":"LineString","coordinates":[[0,0],[1,1]]}`)); err == nil {
+		t.Error("expected unsupported geometry to be rejected")
+	}
+}
+
+// TestFenceEngine walks a vehicle in and out of a circle, checking hysteresis,
+// dwell and per-VIN state.
+func TestFenceEngine(t *testing.T) {
+	home := LocalFence{ID: "home", Center: LatLng{Lat: 40.75, Lng: -73.98}, Radius: 200}
+	e, err := NewFenceEngine([]LocalFence{home}, FenceEngineOptions{Hysteresis: 30, Dwell: 10 * time.Minute})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// north returns a position m meters north of the fence center.
+	north := func(m float64) LatLng { return LatLng{Lat: 40.75 + m/111195.0, Lng: -73.98} }
+	start := time.Date(2026, 5, 1, 15, 0, 0, 0, time.UTC)
+	step := 0
+	update := func(vin string, m float64) []FenceEventType {
+		step++
+		var types []FenceEventType
+		for _, ev := range e.Update(vin, north(m), start.Add(time.Duration(step)*5*time.Minute)) {
+			types = append(types, ev.Type)
+		}
+		return types
+	}
+	expect := func(got []FenceEventType, want ...FenceEventType) {
+		t.Helper()
+		if len(got) != len(want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("expected %v, got %v", want, got)
+			}
+		}
+	}
+
+	expect(update("A", 1000)) // first fix only sets the state
+	expect(update("A", 190))  // inside, but within hysteresis
+	expect(update("A", 150), FenceEnter)
+	expect(update("A", 215)) // outside, but within hysteresis
+	expect(update("A", 100), FenceDwell)
+	expect(update("A", 100)) // dwell reported once per stay
+	expect(update("A", 400), FenceExit)
+
+	expect(update("B", 0)) // other vehicles are tracked separately
+	expect(update("B", 0))
+	expect(update("B", 0), FenceDwell) // already inside when first seen
+
+	if err := e.AddFence(LocalFence{ID: "bad", Polygon: []LatLng{{}, {}}}); err == nil {
+		t.Error("expected polygon with 2 vertices to be rejected")
+	}
+}
+
+// TestFenceEngine_SmallFences verifies fences smaller than the hysteresis can
+// still be entered and left.
+func TestFenceEngine_SmallFences(t *testing.T) {
+	// A 40 m circle and a square about 60 m across, around the same point.
+	center := LatLng{Lat: 40.75, Lng: -73.98}
+	d := 30 / 111195.0
+	e, err := NewFenceEngine([]LocalFence{
+		{ID: "spot", Center: center, Radius: 40},
+		{ID: "lot", Polygon: []LatLng{
+			{Lat: center.Lat - d, Lng: center.Lng - d*1.32},
+			{Lat: center.Lat - d, Lng: center.Lng + d*1.32},
+			{Lat: center.Lat + d, Lng: center.Lng + d*1.32},
+			{Lat: center.Lat + d, Lng: center.Lng - d*1.32},
+		}},
+	}, FenceEngineOptions{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	at := time.Date(2026, 5, 1, 15, 0, 0, 0, time.UTC)
+	far := LatLng{Lat: center.Lat + 1000/111195.0, Lng: center.Lng}
+	e.Update("A", far, at)
+	if events := e.Update("A", center, at.Add(time.Minute)); len(events) != 2 || events[0].Type != FenceEnter || events[1].Type != FenceEnter {
+		t.Errorf("expected both fences to be entered, got %v", events)
+	}
+	if events := e.Update("A", far, at.Add(2*time.Minute)); len(events) != 2 || events[0].Type != FenceExit || events[1].Type != FenceExit {
+		t.Errorf("expected both fences to be left, got %v", events)
+	}
+}
@@ -43,6 +43,45 @@ func (e PINLockedError) Error() string {
 	return fmt.Sprintf("PIN is locked. Try again in %d minutes", e.MinutesRemaining)
 }
 
+// UnsupportedFeatureError reports that a command needs a capability the
+// vehicle does not advertise in its feature codes.
+type UnsupportedFeatureError struct {
+	Feature string
+	Message string
+}
+
+func (e UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("Vehicle does not support [%s]: %s", e.Feature, e.Message)
+}
+
+// ValetSetupError reports that valet mode can't start until it is set up on
+// the vehicle. Hint describes the missing step; Err is the underlying error,
+// such as ErrPINNotSetInHU.
+type ValetSetupError struct {
+	Err  error
+	Hint string
+}
+
+func (e ValetSetupError) Error() string {
+	return fmt.Sprintf("Valet mode is not set up: %s (%v)", e.Hint, e.Err)
+}
+
+func (e ValetSetupError) Unwrap() error {
+	return e.Err
+}
+
+// PolicyDeniedError reports that a PolicyEngine rule blocked a remote
+// command before it was sent.
+type PolicyDeniedError struct {
+	Command RemoteCommand
+	Rule    PolicyRule
+	Message string
+}
+
+func (e PolicyDeniedError) Error() string {
+	return fmt.Sprintf("Policy denied [%s] by rule [%s]: %s", e.Command, e.Rule, e.Message)
+}
+
 // Common API errors
 var (
 	ErrInvalidCredentials   = APIError{Code: "INVALID_CREDENTIALS", Message: "Invalid username or password", Retryable: false}
@@ -58,6 +97,11 @@ var (
 	ErrInvalidPIN           = APIError{Code: "INVALID_PIN", Message: "Invalid PIN code", Retryable: false}
 	ErrServiceInProgress    = APIError{Code: "SERVICE_IN_PROGRESS", Message: "Another service request is already in progress", Retryable: true}
 	ErrTokenGenFailed       = APIError{Code: "TOKEN_GEN_FAILED", Message: "JWT token generation failed", Retryable: true}
+	ErrRefreshTimeout       = APIError{Code: "REFRESH_TIMEOUT", Message: "Vehicle did not report a fresh status in time", Retryable: true}
+	ErrEndpointNotFound     = APIError{Code: "ENDPOINT_NOT_FOUND", Message: "Endpoint not available for this telematics generation", Retryable: false}
+	ErrApprovalDenied       = APIError{Code: "APPROVAL_DENIED", Message: "Command was denied by its approver", Retryable: false}
+	ErrApprovalTimeout      = APIError{Code: "APPROVAL_TIMEOUT", Message: "Command was not approved in time", Retryable: false}
+	ErrMaintenance          = APIError{Code: "MAINTENANCE", Message: "MySubaru backend is down for maintenance", Retryable: true}
 )
 
 // Negative acknowledgement errors (vehicle-side rejections)
@@ -102,6 +146,24 @@ func IsPINLockedError(err error) bool {
 	return errors.As(err, &pinErr)
 }
 
+// IsUnsupportedFeatureError checks if an error is a missing vehicle capability
+func IsUnsupportedFeatureError(err error) bool {
+	var featErr UnsupportedFeatureError
+	return errors.As(err, &featErr)
+}
+
+// IsValetSetupError checks if an error is a missing valet setup step
+func IsValetSetupError(err error) bool {
+	var setupErr ValetSetupError
+	return errors.As(err, &setupErr)
+}
+
+// IsPolicyDeniedError checks if an error is a command blocked by a policy rule
+func IsPolicyDeniedError(err error) bool {
+	var policyErr PolicyDeniedError
+	return errors.As(err, &policyErr)
+}
+
 // IsSessionError reports whether err indicates an expired/invalid session or
 // token — i.e. a condition that warrants re-authentication. It recognizes the
 // typed APIError codes (InvalidToken, INVALID_SESSION, EWC_NoSessionId,
@@ -159,7 +221,9 @@ func ParseAPIError(errorCode string) error {
 	case apiErrors["API_ERROR_G1_INVALID_PIN"]:
 		return ErrInvalidPIN
 	case apiErrors["API_ERROR_G1_PIN_LOCKED"]:
-		return PINLockedError{MinutesRemaining: 30} // Default, actual time may be in response
+		// The remaining time, when reported, is in the error description; see
+		// Client.parseServiceError.
+		return PINLockedError{MinutesRemaining: defaultPINLockMinutes}
 
 	// Negative acknowledgement errors
 	case apiErrors["NACK_ACC_IS_ON"]:
@@ -455,117 +519,121 @@ type GeoPosition struct {
 
 // VehicleStatus .
 type VehicleStatus struct {
-	VehicleId                                int64    `json:"vhsId"`                                        // + 9969776690 5198812434
-	OdometerValue                            int      `json:"odometerValue"`                                // + 23787
-	OdometerValueKm                          int      `json:"odometerValueKilometers"`                      // + 38273
-	EventDate                                UnixTime `json:"eventDate"`                                    // + 1701896993000
-	EventDateStr                             string   `json:"eventDateStr"`                                 // + 2023-12-06T21:09+0000
-	EventDateCarUser                         UnixTime `json:"eventDateCarUser"`                             // + 1701896993000
-	EventDateStrCarUser                      string   `json:"eventDateStrCarUser"`                          // + 2023-12-06T21:09+0000
-	Latitude                                 float64  `json:"latitude"`                                     // + 40.700183
-	Longitude                                float64  `json:"longitude"`                                    // + -74.401372
-	Heading                                  int      `json:"positionHeadingDegree,string"`                 // + "154"
-	DistanceToEmptyFuelMiles                 float64  `json:"distanceToEmptyFuelMiles"`                     // + 209.4
-	DistanceToEmptyFuelKilometers            int      `json:"distanceToEmptyFuelKilometers"`                // + 337
-	DistanceToEmptyFuelMiles10s              int      `json:"distanceToEmptyFuelMiles10s"`                  // + 210
-	DistanceToEmptyFuelKilometers10s         int      `json:"distanceToEmptyFuelKilometers10s"`             // + 340
-	AvgFuelConsumptionMpg                    float64  `json:"avgFuelConsumptionMpg"`                        // + 18.4
-	AvgFuelConsumptionLitersPer100Kilometers float64  `json:"avgFuelConsumptionLitersPer100Kilometers"`     // + 12.8
-	RemainingFuelPercent                     int      `json:"remainingFuelPercent"`                         // + 82
-	TirePressureFrontLeft                    int      `json:"tirePressureFrontLeft,string,omitempty"`       // + "2275"
-	TirePressureFrontRight                   int      `json:"tirePressureFrontRight,string,omitempty"`      // + "2344"
-	TirePressureRearLeft                     int      `json:"tirePressureRearLeft,string,omitempty"`        // + "2413"
-	TirePressureRearRight                    int      `json:"tirePressureRearRight,string,omitempty"`       // + "2344"
-	TirePressureFrontLeftPsi                 float64  `json:"tirePressureFrontLeftPsi,string,omitempty"`    // + "33"
-	TirePressureFrontRightPsi                float64  `json:"tirePressureFrontRightPsi,string,omitempty"`   // + "34"
-	TirePressureRearLeftPsi                  float64  `json:"tirePressureRearLeftPsi,string,omitempty"`     // + "35"
-	TirePressureRearRightPsi                 float64  `json:"tirePressureRearRightPsi,string,omitempty"`    // + "34"
-	TyreStatusFrontLeft                      string   `json:"tyreStatusFrontLeft"`                          // + "UNKNOWN"
-	TyreStatusFrontRight                     string   `json:"tyreStatusFrontRight"`                         // + "UNKNOWN"
-	TyreStatusRearLeft                       string   `json:"tyreStatusRearLeft"`                           // + "UNKNOWN"
-	TyreStatusRearRight                      string   `json:"tyreStatusRearRight"`                          // + "UNKNOWN"
-	EvStateOfChargePercent                   float64  `json:"evStateOfChargePercent,omitempty"`             // + null
-	EvDistanceToEmptyMiles                   int      `json:"evDistanceToEmptyMiles,omitempty"`             // + null
-	EvDistanceToEmptyKilometers              int      `json:"evDistanceToEmptyKilometers,omitempty"`        // + null
-	EvDistanceToEmptyByStateMiles            int      `json:"evDistanceToEmptyByStateMiles,omitempty"`      // + null
-	EvDistanceToEmptyByStateKilometers       int      `json:"evDistanceToEmptyByStateKilometers,omitempty"` // + null
-	VehicleStateType                         string   `json:"vehicleStateType"`                             // + "IGNITION_OFF | IGNITION_ON"
-	WindowFrontLeftStatus                    string   `json:"windowFrontLeftStatus"`                        // CLOSE | VENTED | OPEN
-	WindowFrontRightStatus                   string   `json:"windowFrontRightStatus"`                       // CLOSE | VENTED | OPEN
-	WindowRearLeftStatus                     string   `json:"windowRearLeftStatus"`                         // CLOSE | VENTED | OPEN
-	WindowRearRightStatus                    string   `json:"windowRearRightStatus"`                        // CLOSE | VENTED | OPEN
-	WindowSunroofStatus                      string   `json:"windowSunroofStatus"`                          // CLOSE | SLIDE_PARTLY_OPEN | OPEN | TILT
-	DoorBootPosition                         string   `json:"doorBootPosition"`                             // CLOSED | OPEN
-	DoorEngineHoodPosition                   string   `json:"doorEngineHoodPosition"`                       // CLOSED | OPEN
-	DoorFrontLeftPosition                    string   `json:"doorFrontLeftPosition"`                        // CLOSED | OPEN
-	DoorFrontRightPosition                   string   `json:"doorFrontRightPosition"`                       // CLOSED | OPEN
-	DoorRearLeftPosition                     string   `json:"doorRearLeftPosition"`                         // CLOSED | OPEN
-	DoorRearRightPosition                    string   `json:"doorRearRightPosition"`                        // CLOSED | OPEN
-	DoorBootLockStatus                       string   `json:"doorBootLockStatus"`                           // LOCKED | UNLOCKED
-	DoorFrontLeftLockStatus                  string   `json:"doorFrontLeftLockStatus"`                      // LOCKED | UNLOCKED
-	DoorFrontRightLockStatus                 string   `json:"doorFrontRightLockStatus"`                     // LOCKED | UNLOCKED
-	DoorRearLeftLockStatus                   string   `json:"doorRearLeftLockStatus"`                       // LOCKED | UNLOCKED
-	DoorRearRightLockStatus                  string   `json:"doorRearRightLockStatus"`                      // LOCKED | UNLOCKED
+	VehicleId                                int64       `json:"vhsId"`                                        // + 9969776690 5198812434
+	OdometerValue                            int         `json:"odometerValue"`                                // + 23787
+	OdometerValueKm                          int         `json:"odometerValueKilometers"`                      // + 38273
+	EventDate                                UnixTime    `json:"eventDate"`                                    // + 1701896993000
+	EventDateStr                             string      `json:"eventDateStr"`                                 // + 2023-12-06T21:09+0000
+	EventDateCarUser                         UnixTime    `json:"eventDateCarUser"`                             // + 1701896993000
+	EventDateStrCarUser                      string      `json:"eventDateStrCarUser"`                          // + 2023-12-06T21:09+0000
+	Latitude                                 float64     `json:"latitude"`                                     // + 40.700183
+	Longitude                                float64     `json:"longitude"`                                    // + -74.401372
+	Heading                                  int         `json:"positionHeadingDegree,string"`                 // + "154"
+	DistanceToEmptyFuelMiles                 float64     `json:"distanceToEmptyFuelMiles"`                     // + 209.4
+	DistanceToEmptyFuelKilometers            int         `json:"distanceToEmptyFuelKilometers"`                // + 337
+	DistanceToEmptyFuelMiles10s              int         `json:"distanceToEmptyFuelMiles10s"`                  // + 210
+	DistanceToEmptyFuelKilometers10s         int         `json:"distanceToEmptyFuelKilometers10s"`             // + 340
+	AvgFuelConsumptionMpg                    float64     `json:"avgFuelConsumptionMpg"`                        // + 18.4
+	AvgFuelConsumptionLitersPer100Kilometers float64     `json:"avgFuelConsumptionLitersPer100Kilometers"`     // + 12.8
+	RemainingFuelPercent                     int         `json:"remainingFuelPercent"`                         // + 82
+	TirePressureFrontLeft                    int         `json:"tirePressureFrontLeft,string,omitempty"`       // + "2275"
+	TirePressureFrontRight                   int         `json:"tirePressureFrontRight,string,omitempty"`      // + "2344"
+	TirePressureRearLeft                     int         `json:"tirePressureRearLeft,string,omitempty"`        // + "2413"
+	TirePressureRearRight                    int         `json:"tirePressureRearRight,string,omitempty"`       // + "2344"
+	TirePressureFrontLeftPsi                 float64     `json:"tirePressureFrontLeftPsi,string,omitempty"`    // + "33"
+	TirePressureFrontRightPsi                float64     `json:"tirePressureFrontRightPsi,string,omitempty"`   // + "34"
+	TirePressureRearLeftPsi                  float64     `json:"tirePressureRearLeftPsi,string,omitempty"`     // + "35"
+	TirePressureRearRightPsi                 float64     `json:"tirePressureRearRightPsi,string,omitempty"`    // + "34"
+	TyreStatusFrontLeft                      string      `json:"tyreStatusFrontLeft"`                          // + "UNKNOWN"
+	TyreStatusFrontRight                     string      `json:"tyreStatusFrontRight"`                         // + "UNKNOWN"
+	TyreStatusRearLeft                       string      `json:"tyreStatusRearLeft"`                           // + "UNKNOWN"
+	TyreStatusRearRight                      string      `json:"tyreStatusRearRight"`                          // + "UNKNOWN"
+	EvStateOfChargePercent                   float64     `json:"evStateOfChargePercent,omitempty"`             // + null
+	EvDistanceToEmptyMiles                   int         `json:"evDistanceToEmptyMiles,omitempty"`             // + null
+	EvDistanceToEmptyKilometers              int         `json:"evDistanceToEmptyKilometers,omitempty"`        // + null
+	EvDistanceToEmptyByStateMiles            int         `json:"evDistanceToEmptyByStateMiles,omitempty"`      // + null
+	EvDistanceToEmptyByStateKilometers       int         `json:"evDistanceToEmptyByStateKilometers,omitempty"` // + null
+	VehicleStateType                         string      `json:"vehicleStateType"`                             // + "IGNITION_OFF | IGNITION_ON"
+	OutsideTemp                              json.Number `json:"outsideTemp,omitempty"`                        // EXT_EXTERNAL_TEMP, °C, "-64.0" when unavailable
+	WindowFrontLeftStatus                    string      `json:"windowFrontLeftStatus"`                        // CLOSE | VENTED | OPEN
+	WindowFrontRightStatus                   string      `json:"windowFrontRightStatus"`                       // CLOSE | VENTED | OPEN
+	WindowRearLeftStatus                     string      `json:"windowRearLeftStatus"`                         // CLOSE | VENTED | OPEN
+	WindowRearRightStatus                    string      `json:"windowRearRightStatus"`                        // CLOSE | VENTED | OPEN
+	WindowSunroofStatus                      string      `json:"windowSunroofStatus"`                          // CLOSE | SLIDE_PARTLY_OPEN | OPEN | TILT
+	DoorBootPosition                         string      `json:"doorBootPosition"`                             // CLOSED | OPEN
+	DoorEngineHoodPosition                   string      `json:"doorEngineHoodPosition"`                       // CLOSED | OPEN
+	DoorFrontLeftPosition                    string      `json:"doorFrontLeftPosition"`                        // CLOSED | OPEN
+	DoorFrontRightPosition                   string      `json:"doorFrontRightPosition"`                       // CLOSED | OPEN
+	DoorRearLeftPosition                     string      `json:"doorRearLeftPosition"`                         // CLOSED | OPEN
+	DoorRearRightPosition                    string      `json:"doorRearRightPosition"`                        // CLOSED | OPEN
+	DoorBootLockStatus                       string      `json:"doorBootLockStatus"`                           // LOCKED | UNLOCKED
+	DoorFrontLeftLockStatus                  string      `json:"doorFrontLeftLockStatus"`                      // LOCKED | UNLOCKED
+	DoorFrontRightLockStatus                 string      `json:"doorFrontRightLockStatus"`                     // LOCKED | UNLOCKED
+	DoorRearLeftLockStatus                   string      `json:"doorRearLeftLockStatus"`                       // LOCKED | UNLOCKED
+	DoorRearRightLockStatus                  string      `json:"doorRearRightLockStatus"`                      // LOCKED | UNLOCKED
 }
 
 // VehicleCondition .
 // "dataName":"remoteServiceStatus"
 // "remoteServiceType":"condition"
 type VehicleCondition struct {
-	VehicleStateType           string  `json:"vehicleStateType"`                 // "IGNITION_OFF | IGNITION_ON"
-	AvgFuelConsumption         float64 `json:"avgFuelConsumption,omitempty"`     // null | 18.4
-	AvgFuelConsumptionUnit     string  `json:"avgFuelConsumptionUnit"`           // "MPG"
-	DistanceToEmptyFuel        int     `json:"distanceToEmptyFuel,omitempty"`    // null | 160
-	DistanceToEmptyFuelUnit    string  `json:"distanceToEmptyFuelUnit"`          // "MILES"
-	RemainingFuelPercent       int     `json:"remainingFuelPercent,string"`      // "66"
-	Odometer                   int     `json:"odometer"`                         // 92
-	OdometerUnit               string  `json:"odometerUnit"`                     // "MILES"
-	TirePressureFrontLeft      float64 `json:"tirePressureFrontLeft,omitempty"`  // null | 36
-	TirePressureFrontLeftUnit  string  `json:"tirePressureFrontLeftUnit"`        // "PSI"
-	TirePressureFrontRight     float64 `json:"tirePressureFrontRight,omitempty"` // null | 36
-	TirePressureFrontRightUnit string  `json:"tirePressureFrontRightUnit"`       // "PSI",
-	TirePressureRearLeft       float64 `json:"tirePressureRearLeft,omitempty"`   // null | 36
-	TirePressureRearLeftUnit   string  `json:"tirePressureRearLeftUnit"`         // "PSI"
-	TirePressureRearRight      float64 `json:"tirePressureRearRight,omitempty"`  // null | 36
-	TirePressureRearRightUnit  string  `json:"tirePressureRearRightUnit"`        // "PSI"
-	DoorBootPosition           string  `json:"doorBootPosition"`                 // "CLOSED | OPEN"
-	DoorEngineHoodPosition     string  `json:"doorEngineHoodPosition"`           // "CLOSED | OPEN"
-	DoorFrontLeftPosition      string  `json:"doorFrontLeftPosition"`            // "CLOSED | OPEN"
-	DoorFrontRightPosition     string  `json:"doorFrontRightPosition"`           // "CLOSED | OPEN"
-	DoorRearLeftPosition       string  `json:"doorRearLeftPosition"`             // "CLOSED | OPEN"
-	DoorRearRightPosition      string  `json:"doorRearRightPosition"`            // "CLOSED | OPEN"
-	WindowFrontLeftStatus      string  `json:"windowFrontLeftStatus"`            // "CLOSE | VENTED | OPEN"
-	WindowFrontRightStatus     string  `json:"windowFrontRightStatus"`           // "CLOSE | VENTED | OPEN"
-	WindowRearLeftStatus       string  `json:"windowRearLeftStatus"`             // "CLOSE | VENTED | OPEN"
-	WindowRearRightStatus      string  `json:"windowRearRightStatus"`            // "CLOSE | VENTED | OPEN"
-	WindowSunroofStatus        string  `json:"windowSunroofStatus"`              // "CLOSE | VENTED | OPEN"
-	EvDistanceToEmpty          int     `json:"evDistanceToEmpty,omitempty"`      // null,
-	EvDistanceToEmptyUnit      string  `json:"evDistanceToEmptyUnit,omitempty"`  // null,
-	EvChargerStateType         string  `json:"evChargerStateType,omitempty"`     // null,
-	EvIsPluggedIn              bool    `json:"evIsPluggedIn,omitempty"`          // null,
-	EvStateOfChargeMode        string  `json:"evStateOfChargeMode,omitempty"`    // null,
-	EvTimeToFullyCharged       string  `json:"evTimeToFullyCharged,omitempty"`   // null,
-	EvStateOfChargePercent     int     `json:"evStateOfChargePercent,omitempty"` // null,
-	LastUpdatedTime            string  `json:"lastUpdatedTime"`                  // "2023-04-10T17:50:54+0000",
+	VehicleStateType           string          `json:"vehicleStateType"`                  // "IGNITION_OFF | IGNITION_ON"
+	AvgFuelConsumption         float64         `json:"avgFuelConsumption,omitempty"`      // null | 18.4
+	AvgFuelConsumptionUnit     string          `json:"avgFuelConsumptionUnit"`            // "MPG"
+	DistanceToEmptyFuel        int             `json:"distanceToEmptyFuel,omitempty"`     // null | 160
+	DistanceToEmptyFuelUnit    string          `json:"distanceToEmptyFuelUnit"`           // "MILES"
+	RemainingFuelPercent       int             `json:"remainingFuelPercent,string"`       // "66"
+	Odometer                   int             `json:"odometer"`                          // 92
+	OdometerUnit               string          `json:"odometerUnit"`                      // "MILES"
+	TirePressureFrontLeft      float64         `json:"tirePressureFrontLeft,omitempty"`   // null | 36
+	TirePressureFrontLeftUnit  string          `json:"tirePressureFrontLeftUnit"`         // "PSI"
+	TirePressureFrontRight     float64         `json:"tirePressureFrontRight,omitempty"`  // null | 36
+	TirePressureFrontRightUnit string          `json:"tirePressureFrontRightUnit"`        // "PSI",
+	TirePressureRearLeft       float64         `json:"tirePressureRearLeft,omitempty"`    // null | 36
+	TirePressureRearLeftUnit   string          `json:"tirePressureRearLeftUnit"`          // "PSI"
+	TirePressureRearRight      float64         `json:"tirePressureRearRight,omitempty"`   // null | 36
+	TirePressureRearRightUnit  string          `json:"tirePressureRearRightUnit"`         // "PSI"
+	DoorBootPosition           string          `json:"doorBootPosition"`                  // "CLOSED | OPEN"
+	DoorEngineHoodPosition     string          `json:"doorEngineHoodPosition"`            // "CLOSED | OPEN"
+	DoorFrontLeftPosition      string          `json:"doorFrontLeftPosition"`             // "CLOSED | OPEN"
+	DoorFrontRightPosition     string          `json:"doorFrontRightPosition"`            // "CLOSED | OPEN"
+	DoorRearLeftPosition       string          `json:"doorRearLeftPosition"`              // "CLOSED | OPEN"
+	DoorRearRightPosition      string          `json:"doorRearRightPosition"`             // "CLOSED | OPEN"
+	WindowFrontLeftStatus      string          `json:"windowFrontLeftStatus"`             // "CLOSE | VENTED | OPEN"
+	WindowFrontRightStatus     string          `json:"windowFrontRightStatus"`            // "CLOSE | VENTED | OPEN"
+	WindowRearLeftStatus       string          `json:"windowRearLeftStatus"`              // "CLOSE | VENTED | OPEN"
+	WindowRearRightStatus      string          `json:"windowRearRightStatus"`             // "CLOSE | VENTED | OPEN"
+	WindowSunroofStatus        string          `json:"windowSunroofStatus"`               // "CLOSE | VENTED | OPEN"
+	EvDistanceToEmpty          int             `json:"evDistanceToEmpty,omitempty"`       // null,
+	EvDistanceToEmptyUnit      string          `json:"evDistanceToEmptyUnit,omitempty"`   // null,
+	EvChargerStateType         string          `json:"evChargerStateType,omitempty"`      // null,
+	EvIsPluggedIn              json.RawMessage `json:"evIsPluggedIn,omitempty"`           // null | "UNLOCKED_CONNECTED" | "LOCKED_CONNECTED" | "NOT_CONNECTED"
+	EvStateOfChargeMode        string          `json:"evStateOfChargeMode,omitempty"`     // null,
+	EvTimeToFullyCharged       string          `json:"evTimeToFullyCharged,omitempty"`    // null | "120" (minutes) | "65535"
+	EvTimeToFullyChargedUTC    string          `json:"evTimeToFullyChargedUTC,omitempty"` // null | "2023-04-10T19:50:54+0000"
+	EvStateOfChargePercent     json.Number     `json:"evStateOfChargePercent,omitempty"`  // null | "60"
+	OutsideTemp                json.Number     `json:"outsideTemp,omitempty"`             // EXT_EXTERNAL_TEMP, °C, "-64.0" when unavailable
+	LastUpdatedTime            string          `json:"lastUpdatedTime"`                   // "2023-04-10T17:50:54+0000",
 }
 
 // ClimateProfile represents a climate control profile for a Subaru vehicle.
 type ClimateProfile struct {
 	Name                      string `json:"name"`
-	VehicleType               string `json:"vehicleType,omitempty"`       // vehicleType                  [ gas | phev ]
-	PresetType                string `json:"presetType"`                  // presetType                   [ subaruPreset | userPreset ]
-	StartConfiguration        string `json:"startConfiguration"`          // startConfiguration           [ START_ENGINE_ALLOW_KEY_IN_IGNITION (gas) | START_CLIMATE_CONTROL_ONLY_ALLOW_KEY_IN_IGNITION (phev) ]
-	RunTimeMinutes            int    `json:"runTimeMinutes,string"`       // runTimeMinutes               [ 0 | 1 | 5 | 10 ]
-	HeatedRearWindowActive    string `json:"heatedRearWindowActive"`      // heatedRearWindowActive:      [ false | true ]
-	HeatedSeatFrontRight      string `json:"heatedSeatFrontRight"`        // heatedSeatFrontRight:        [ OFF | LOW_HEAT | MEDIUM_HEAT | HIGH_HEAT ]
-	HeatedSeatFrontLeft       string `json:"heatedSeatFrontLeft"`         // heatedSeatFrontLeft:         [ OFF | LOW_HEAT | MEDIUM_HEAT | HIGH_HEAT ]
-	ClimateZoneFrontTemp      int    `json:"climateZoneFrontTemp,string"` // climateZoneFrontTemp:        [ for _ in range(60, 85 + 1)] // climateZoneFrontTempCelsius: [for _ in range(15, 30 + 1) ]
-	ClimateZoneFrontAirMode   string `json:"climateZoneFrontAirMode"`     // climateZoneFrontAirMode:     [ WINDOW | FEET_WINDOW | FACE | FEET | FEET_FACE_BALANCED | AUTO ]
-	ClimateZoneFrontAirVolume string `json:"climateZoneFrontAirVolume"`   // climateZoneFrontAirVolume:   [ AUTO | 2 | 4 | 7 ]
-	OuterAirCirculation       string `json:"outerAirCirculation"`         // airConditionOn:              [ auto | outsideAir | true ]
-	AirConditionOn            string `json:"airConditionOn"`              // airConditionOn:              [ false | true ]
-	CanEdit                   string `json:"canEdit"`                     // canEdit                      [ false | true ]
-	Disabled                  string `json:"disabled"`                    // disabled                     [ false | true ]
+	VehicleType               string `json:"vehicleType,omitempty"`                        // vehicleType                  [ gas | phev ]
+	PresetType                string `json:"presetType"`                                   // presetType                   [ subaruPreset | userPreset ]
+	StartConfiguration        string `json:"startConfiguration"`                           // startConfiguration           [ START_ENGINE_ALLOW_KEY_IN_IGNITION (gas) | START_CLIMATE_CONTROL_ONLY_ALLOW_KEY_IN_IGNITION (phev) ]
+	RunTimeMinutes            int    `json:"runTimeMinutes,string"`                        // runTimeMinutes               [ 0 | 1 | 5 | 10 ]
+	HeatedRearWindowActive    string `json:"heatedRearWindowActive"`                       // heatedRearWindowActive:      [ false | true ]
+	HeatedSeatFrontRight      string `json:"heatedSeatFrontRight"`                         // heatedSeatFrontRight:        [ OFF | LOW_HEAT | MEDIUM_HEAT | HIGH_HEAT ]
+	HeatedSeatFrontLeft       string `json:"heatedSeatFrontLeft"`                          // heatedSeatFrontLeft:         [ OFF | LOW_HEAT | MEDIUM_HEAT | HIGH_HEAT ]
+	ClimateZoneFrontTemp      int    `json:"climateZoneFrontTemp,string,omitempty"`        // climateZoneFrontTemp:        [ for _ in range(60, 85 + 1)]
+	ClimateZoneFrontTempC     int    `json:"climateZoneFrontTempCelsius,string,omitempty"` // climateZoneFrontTempCelsius: [ for _ in range(15, 30 + 1) ]
+	ClimateZoneFrontAirMode   string `json:"climateZoneFrontAirMode"`                      // climateZoneFrontAirMode:     [ WINDOW | FEET_WINDOW | FACE | FEET | FEET_FACE_BALANCED | AUTO ]
+	ClimateZoneFrontAirVolume string `json:"climateZoneFrontAirVolume"`                    // climateZoneFrontAirVolume:   [ AUTO | 2 | 4 | 7 ]
+	OuterAirCirculation       string `json:"outerAirCirculation"`                          // airConditionOn:              [ auto | outsideAir | true ]
+	AirConditionOn            string `json:"airConditionOn"`                               // airConditionOn:              [ false | true ]
+	CanEdit                   string `json:"canEdit"`                                      // canEdit                      [ false | true ]
+	Disabled                  string `json:"disabled"`                                     // disabled                     [ false | true ]
 }
 
 type ClimateProfiles map[string]ClimateProfile
@@ -590,6 +658,7 @@ type ServiceRequest struct {
 	RemoteServiceState string          `json:"remoteServiceState"`         // started | finished | stopping
 	SubState           string          `json:"subState,omitempty"`         // null
 	ErrorCode          string          `json:"errorCode,omitempty"`        // null:null
+	ErrorDescription   string          `json:"errorDescription,omitempty"` // null
 	Result             json.RawMessage `json:"result,omitempty"`           // struct
 	UpdateTime         UnixTime        `json:"updateTime,omitempty"`       // timestamp // is empty if the request is started
 }
@@ -642,16 +711,29 @@ type UnixTime struct {
 
 // UnmarshalJSON is the method that satisfies the Unmarshaller interface
 // Note that it uses a pointer receiver. It needs this because it will be modifying the embedded time.Time instance
+// Timestamps in milliseconds, as the status endpoint reports eventDate, are
+// told apart by their size; null leaves the zero time.
 func (u *UnixTime) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		u.Time = time.Time{}
+		return nil
+	}
 	var timestamp int64
 	err := json.Unmarshal(b, &timestamp)
 	if err != nil {
 		return err
 	}
+	if timestamp > unixMillisThreshold {
+		u.Time = time.UnixMilli(timestamp)
+		return nil
+	}
 	u.Time = time.Unix(timestamp, 0)
 	return nil
 }
 
+// unixMillisThreshold is the largest timestamp read as seconds (year 5138).
+const unixMillisThreshold = 1e11
+
 // MarshalJSON turns our time.Time back into an int
 func (u UnixTime) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf("%d", u.Unix())), nil
@@ -39,6 +39,15 @@ func TestUnixTime_UnmarshalJSON(t *testing.T) {
 			input:    "0",
 			wantTime: time.Unix(0, 0),
 		},
+		{
+			name:     "milliseconds",
+			input:    "1751742945000",
+			wantTime: time.UnixMilli(1751742945000),
+		},
+		{
+			name:  "null",
+			input: "null",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,246 @@
+package mysubaru
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// PIN Supplier
+// =============================================================================
+
+// PINSupplier provides the remote-services PIN each time a command needs it,
+// so the PIN can be prompted for or fetched from a secret store instead of
+// living in the configuration.
+type PINSupplier interface {
+	PIN(ctx context.Context, vin string) (string, error)
+}
+
+// PINSupplierFunc adapts a function to a PINSupplier.
+type PINSupplierFunc func(ctx context.Context, vin string) (string, error)
+
+// PIN calls f.
+func (f PINSupplierFunc) PIN(ctx context.Context, vin string) (string, error) {
+	return f(ctx, vin)
+}
+
+// StaticPIN is a PINSupplier always providing the same PIN. A client supplies
+// the configured credentials PIN this way unless SetPINSupplier is called.
+type StaticPIN string
+
+// PIN returns p.
+func (p StaticPIN) PIN(context.Context, string) (string, error) {
+	return string(p), nil
+}
+
+// pinSource holds the client's PINSupplier.
+type pinSource struct {
+	supplier PINSupplier
+}
+
+// SetPINSupplier makes the client ask s for the PIN of every PIN-bearing
+// command. A nil s restores the configured credentials PIN.
+func (c *Client) SetPINSupplier(s PINSupplier) {
+	if s == nil {
+		c.pinSupplier.Store(nil)
+		return
+	}
+	c.pinSupplier.Store(&pinSource{supplier: s})
+}
+
+// remotePIN asks the client's PINSupplier for the PIN of a command to vin.
+// During a PIN lockout it fails without asking.
+func (c *Client) remotePIN(ctx context.Context, vin string) (string, error) {
+	if err := c.checkPINLockout(); err != nil {
+		return "", err
+	}
+	var s PINSupplier = StaticPIN(c.credentials.PIN)
+	if src := c.pinSupplier.Load(); src != nil {
+		s = src.supplier
+	}
+	pin, err := s.PIN(ctx, vin)
+	if err != nil {
+		return "", fmt.Errorf("failed to get remote services PIN: %w", err)
+	}
+	return pin, nil
+}
+
+// remotePIN asks the client's PINSupplier for the PIN of a command to v.
+func (v *Vehicle) remotePIN(ctx context.Context) (string, error) {
+	return v.client.remotePIN(ctx, v.Vin)
+}
+
+// =============================================================================
+// PIN Lockout
+// =============================================================================
+
+// defaultPINLockMinutes is assumed when a PIN lockout doesn't say how long it
+// lasts.
+const defaultPINLockMinutes = 30
+
+// pinLockDurationRe matches a lockout duration in an error description, e.g.
+// "PIN locked. Try again in 25 minutes".
+var pinLockDurationRe = regexp.MustCompile(`(?i)(\d+)\s*(hours?|hrs?|minutes?|mins?|seconds?|secs?)\b`)
+
+// PINStatus reports the remote-services PIN state the client has observed.
+type PINStatus struct {
+	// Failures counts invalid-PIN rejections since the PIN was last accepted.
+	Failures    int
+	LastFailure time.Time
+	// LockedUntil is when the current lockout ends; zero when not locked.
+	LockedUntil time.Time
+	// Rejected reports that the vehicle rejected the last PIN sent. Commands
+	// carrying that PIN are refused locally until another PIN is accepted or
+	// ResetPINStatus is called.
+	Rejected bool
+}
+
+// Locked reports whether a lockout is in effect.
+func (s PINStatus) Locked() bool {
+	return time.Now().Before(s.LockedUntil)
+}
+
+// pinGuard tracks PIN failures so a rejected PIN isn't sent again to extend a
+// lockout. It keeps hashes of PINs, never the PINs themselves.
+type pinGuard struct {
+	mu          sync.Mutex
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+	lastSent    [sha256.Size]byte // PIN of the last PIN-bearing request
+	rejected    [sha256.Size]byte // PIN the vehicle last rejected
+	hasRejected bool
+}
+
+// PINStatus returns the PIN state observed by the client.
+func (c *Client) PINStatus() PINStatus {
+	g := &c.pin
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	s := PINStatus{Failures: g.failures, LastFailure: g.lastFailure}
+	if time.Now().Before(g.lockedUntil) {
+		s.LockedUntil = g.lockedUntil
+	}
+	s.Rejected = g.hasRejected
+	return s
+}
+
+// ResetPINStatus forgets recorded PIN failures and rejections, for instance
+// after the PIN was changed in the MySubaru app. A lockout reported by the
+// vehicle still applies.
+func (c *Client) ResetPINStatus() {
+	g := &c.pin
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.failures, g.lastFailure, g.hasRejected = 0, time.Time{}, false
+}
+
+// checkPIN refuses a request carrying pin while the PIN is locked out or when
+// pin was already rejected.
+func (c *Client) checkPIN(pin string) error {
+	if err := c.checkPINLockout(); err != nil {
+		return err
+	}
+	g := &c.pin
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.hasRejected && g.rejected == sha256.Sum256([]byte(pin)) {
+		return fmt.Errorf("not resending a PIN the vehicle rejected: %w", ErrInvalidPIN)
+	}
+	return nil
+}
+
+// checkPINLockout returns a PINLockedError while a PIN lockout is in effect.
+func (c *Client) checkPINLockout() error {
+	g := &c.pin
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if remaining := time.Until(g.lockedUntil); remaining > 0 {
+		return PINLockedError{MinutesRemaining: int(math.Ceil(remaining.Minutes()))}
+	}
+	return nil
+}
+
+// pinSent notes pin as the PIN of the request being sent, so failures reported
+// later while polling are attributed to it.
+func (c *Client) pinSent(pin string) {
+	g := &c.pin
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.lastSent = sha256.Sum256([]byte(pin))
+}
+
+// pinAccepted clears recorded failures after a service request finished
+// successfully. A successful post doesn't prove the PIN: G2 reports an invalid
+// PIN later, in the service request status.
+func (c *Client) pinAccepted() {
+	g := &c.pin
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.failures, g.hasRejected = 0, false
+}
+
+// pinFailed records err when it is an invalid-PIN rejection or a lockout.
+func (c *Client) pinFailed(err error) {
+	var locked PINLockedError
+	isLocked := errors.As(err, &locked)
+	if !isLocked && !errors.Is(err, ErrInvalidPIN) {
+		return
+	}
+	g := &c.pin
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	now := time.Now()
+	if isLocked {
+		g.lockedUntil = now.Add(time.Duration(locked.MinutesRemaining) * time.Minute)
+		c.logger.Warn("remote services PIN is locked", "until", g.lockedUntil)
+		return
+	}
+	g.failures++
+	g.lastFailure = now
+	g.rejected, g.hasRejected = g.lastSent, true
+	c.logger.Warn("remote services PIN was rejected", "failures", g.failures)
+}
+
+// parseServiceError maps a failed request's error code to its typed error,
+// taking a PIN lockout's remaining time from description when it states one,
+// and records PIN failures.
+func (c *Client) parseServiceError(code, description string) error {
+	err := ParseAPIError(code)
+	if _, ok := err.(PINLockedError); ok {
+		if minutes, ok := parsePINLockMinutes(description); ok {
+			err = PINLockedError{MinutesRemaining: minutes}
+		}
+	}
+	c.pinFailed(err)
+	return err
+}
+
+// parsePINLockMinutes reads a lockout duration from an error description,
+// rounded up to whole minutes.
+func parsePINLockMinutes(description string) (int, bool) {
+	m := pinLockDurationRe.FindStringSubmatch(description)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, false
+	}
+	switch unit := strings.ToLower(m[2]); {
+	case strings.HasPrefix(unit, "h"):
+		return n * 60, true
+	case strings.HasPrefix(unit, "s"):
+		return (n + 59) / 60, true
+	default:
+		return n, true
+	}
+}
@@ -0,0 +1,179 @@
+package mysubaru
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParsePINLockMinutes checks lockout durations are read from error
+// descriptions.
+func TestParsePINLockMinutes(t *testing.T) {
+	tests := []struct {
+		description string
+		want        int
+		ok          bool
+	}{
+		{"PIN locked. Try again in 25 minutes", 25, true},
+		{"Locked for 1 hour", 60, true},
+		{"retry after 90 seconds", 2, true},
+		{"PIN is locked", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parsePINLockMinutes(tt.description)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%q: expected %d, %v, got %d, %v", tt.description, tt.want, tt.ok, got, ok)
+		}
+	}
+}
+
+// TestPINLockout verifies a reported lockout is tracked with its stated
+// duration and PIN-bearing commands are refused locally until it ends.
+func TestPINLockout(t *testing.T) {
+	v, sent := setupCountingVehicle(t, false, endpointRoute{
+		Method:   http.MethodPost,
+		Path:     apiURLs["API_UNLOCK"],
+		Response: `{"success":false,"errorCode":"SXM40017","dataName":"errorResponse","data":{"errorLabel":"SXM40017","errorDescription":"PIN locked. Try again in 12 minutes"}}`,
+	})
+	ctx := context.Background()
+
+	var locked PINLockedError
+	if err := v.client.RemoteUnlock(ctx, v.Vin); !errors.As(err, &locked) || locked.MinutesRemaining != 12 {
+		t.Fatalf("expected a 12 minute PINLockedError, got %v", err)
+	}
+	status := v.client.PINStatus()
+	if !status.Locked() || time.Until(status.LockedUntil) > 12*time.Minute || time.Until(status.LockedUntil) < 11*time.Minute {
+		t.Errorf("expected a 12 minute lockout, got %+v", status)
+	}
+
+	if _, err := v.Lock(ctx); !IsPINLockedError(err) {
+		t.Errorf("expected lock to be refused during the lockout, got %v", err)
+	}
+	if err := v.client.RemoteUnlock(ctx, v.Vin); !IsPINLockedError(err) {
+		t.Errorf("expected unlock to be refused during the lockout, got %v", err)
+	}
+	if n := sent.Load(); n != 1 {
+		t.Errorf("expected only the first unlock to be sent, got %d requests", n)
+	}
+}
+
+// TestPINRejected verifies a rejected PIN is not sent again until the status
+// is reset.
+func TestPINRejected(t *testing.T) {
+	v, sent := setupCountingVehicle(t, false, endpointRoute{
+		Method:   http.MethodPost,
+		Path:     apiURLs["API_UNLOCK"],
+		Response: `{"success":false,"errorCode":"SXM40006","dataName":null,"data":null}`,
+	})
+	ctx := context.Background()
+
+	if err := v.client.RemoteUnlock(ctx, v.Vin); !errors.Is(err, ErrInvalidPIN) {
+		t.Fatalf("expected ErrInvalidPIN, got %v", err)
+	}
+	if status := v.client.PINStatus(); status.Failures != 1 || !status.Rejected || status.Locked() {
+		t.Errorf("expected one failure and a rejected PIN, got %+v", status)
+	}
+	if err := v.client.RemoteUnlock(ctx, v.Vin); !errors.Is(err, ErrInvalidPIN) {
+		t.Errorf("expected the rejected PIN to be refused, got %v", err)
+	}
+	if n := sent.Load(); n != 1 {
+		t.Errorf("expected the rejected PIN to be sent once, got %d requests", n)
+	}
+
+	v.client.ResetPINStatus()
+	if status := v.client.PINStatus(); status.Failures != 0 || status.Rejected {
+		t.Errorf("expected a clean status after reset, got %+v", status)
+	}
+	_ = v.client.RemoteUnlock(ctx, v.Vin)
+	if n := sent.Load(); n != 2 {
+		t.Errorf("expected the PIN to be sent again after reset, got %d requests", n)
+	}
+}
+
+// TestPINSupplier verifies the PIN is asked for at command time, and a new PIN
+// from the supplier is sent after the previous one was rejected.
+func TestPINSupplier(t *testing.T) {
+	v, sent := setupCountingVehicle(t, false, endpointRoute{
+		Method:   http.MethodPost,
+		Path:     apiURLs["API_UNLOCK"],
+		Response: `{"success":false,"errorCode":"SXM40006","dataName":null,"data":null}`,
+	})
+	ctx := context.Background()
+
+	var asked []string
+	pins := []string{"1111", "1111", "2222"}
+	v.client.SetPINSupplier(PINSupplierFunc(func(_ context.Context, vin string) (string, error) {
+		asked = append(asked, vin)
+		if len(asked) > len(pins) {
+			return "", errors.New("prompt cancelled")
+		}
+		return pins[len(asked)-1], nil
+	}))
+
+	if err := v.client.RemoteUnlock(ctx, v.Vin); !errors.Is(err, ErrInvalidPIN) {
+		t.Fatalf("expected ErrInvalidPIN, got %v", err)
+	}
+	if _, err := v.Unlock(ctx); !errors.Is(err, ErrInvalidPIN) {
+		t.Errorf("expected the rejected PIN to be refused, got %v", err)
+	}
+	_ = v.client.RemoteUnlock(ctx, v.Vin)
+	if n := sent.Load(); n != 2 {
+		t.Errorf("expected the first and the new PIN to be sent, got %d requests", n)
+	}
+	if _, err := v.Lock(ctx); err == nil || !strings.Contains(err.Error(), "prompt cancelled") {
+		t.Errorf("expected the supplier error, got %v", err)
+	}
+	if len(asked) != 4 || asked[0] != v.Vin {
+		t.Errorf("expected the supplier to be asked for each command with the VIN, got %v", asked)
+	}
+
+	v.client.SetPINSupplier(nil)
+	if pin, err := v.remotePIN(ctx); err != nil || pin != "1234" {
+		t.Errorf("expected the configured PIN after removing the supplier, got %q, %v", pin, err)
+	}
+}
+
+// TestPINFailuresAccumulate verifies invalid-PIN verdicts arriving in the
+// service request status add up, and only a finished request clears them.
+func TestPINFailuresAccumulate(t *testing.T) {
+	v, _ := setupCountingVehicle(t, false, endpointRoute{
+		Method:   http.MethodPost,
+		Path:     apiURLs["API_UNLOCK"],
+		Response: `{"success":true,"errorCode":null,"dataName":"remoteServiceStatus","data":{"serviceRequestId":null,"success":false,"cancelled":false,"remoteServiceType":"unlock","remoteServiceState":"finished","errorCode":"SXM40006","errorDescription":null}}`,
+	})
+	ctx := context.Background()
+	pins := []string{"1111", "2222"}
+	v.client.SetPINSupplier(PINSupplierFunc(func(context.Context, string) (string, error) {
+		pin := pins[0]
+		pins = pins[1:]
+		return pin, nil
+	}))
+
+	for range 2 {
+		ch, err := v.Unlock(ctx)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for range ch {
+		}
+	}
+	if status := v.client.PINStatus(); status.Failures != 2 || !status.Rejected {
+		t.Errorf("expected two failures, got %+v", status)
+	}
+
+	resp := &Response{
+		Success:  true,
+		DataName: "remoteServiceStatus",
+		Data:     []byte(`{"serviceRequestId":null,"success":true,"cancelled":false,"remoteServiceType":"unlock","remoteServiceState":"finished","errorCode":null,"result":null}`),
+	}
+	if err := v.handleServiceResponse(ctx, resp, "", "", make(chan string, 1), 2); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if status := v.client.PINStatus(); status.Failures != 0 || status.Rejected {
+		t.Errorf("expected a finished request to clear the failures, got %+v", status)
+	}
+}
@@ -0,0 +1,298 @@
+package mysubaru
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// =============================================================================
+// Command Policy
+// =============================================================================
+
+// RemoteCommand names a remote command by its service endpoint, e.g. "unlock"
+// or "engineStart".
+type RemoteCommand string
+
+const (
+	CommandLock        RemoteCommand = "lock"
+	CommandUnlock      RemoteCommand = "unlock"
+	CommandEngineStart RemoteCommand = "engineStart"
+	CommandEngineStop  RemoteCommand = "engineStop"
+	CommandHornLights  RemoteCommand = "hornLights"
+	CommandLightsOnly  RemoteCommand = "lightsOnly"
+	CommandValetMode   RemoteCommand = "valetMode"
+)
+
+// PolicyRule identifies the Policy rule behind a decision.
+type PolicyRule string
+
+const (
+	RuleStolenVehicle    PolicyRule = "stolen_vehicle"
+	RuleUnlockHours      PolicyRule = "unlock_hours"
+	RuleUnlockLocation   PolicyRule = "unlock_location"
+	RuleEngineStartLimit PolicyRule = "engine_start_limit"
+)
+
+// policyAuditSize caps the decisions a PolicyEngine keeps.
+const policyAuditSize = 1000
+
+// serviceCommandRe matches the command and action of a remote service path,
+// e.g. "/service/g2/unlock/execute.json".
+var serviceCommandRe = regexp.MustCompile(`/service/[^/]+/([^/]+)/([^/]+)\.json$`)
+
+// Policy declares which remote commands may be sent. Zero-valued rules are
+// off. Times are evaluated in the vehicle's time zone.
+type Policy struct {
+	// UnlockHours, when set, allows unlocking only inside one of the windows.
+	UnlockHours []CurfewWindow
+	// UnlockRadius, when positive, allows unlocking only while the vehicle's
+	// last known location is within that many meters of one of UnlockPOIs
+	// (for instance the favorites from GetFavoritePOIs).
+	UnlockRadius float64
+	UnlockPOIs   []POI
+	// MaxEngineStartsPerDay, when positive, caps the engine starts allowed per
+	// vehicle and calendar day.
+	MaxEngineStartsPerDay int
+	// DenyStolen denies every command while the vehicle is reported stolen.
+	DenyStolen bool
+}
+
+// CommandRequest describes a remote command about to be sent.
+type CommandRequest struct {
+	Command  RemoteCommand     `json:"command"`
+	Action   string            `json:"action"` // execute | cancel | stop, or the action parameter (e.g. valet mode start | stop)
+	Vin      string            `json:"vin"`
+	Location LatLng            `json:"location"` // the vehicle's last known location
+	Stolen   bool              `json:"stolen"`   // the vehicle is reported stolen
+	Params   map[string]string `json:"params"`   // request parameters; PINs read "[REDACTED]"
+	Time     time.Time         `json:"time"`     // in the vehicle's time zone
+	DryRun   bool              `json:"dryRun"`   // simulated; doesn't count toward limits
+}
+
+// PolicyDecision is an audit log entry of a PolicyEngine.
+type PolicyDecision struct {
+	Time    time.Time
+	Vin     string
+	Command RemoteCommand
+	Action  string
+	Allowed bool
+	Rule    PolicyRule // rule that denied the command
+	Reason  string
+	DryRun  bool
+}
+
+// PolicyEngine checks remote commands against a Policy and keeps an audit
+// log of its decisions. One engine can be shared by several clients so their
+// engine starts count against the same limit. It is safe for concurrent use.
+type PolicyEngine struct {
+	policy Policy
+
+	mu     sync.Mutex
+	starts map[string][]time.Time // allowed engine starts by VIN
+	audit  []PolicyDecision
+}
+
+// NewPolicyEngine returns an engine enforcing p.
+func NewPolicyEngine(p Policy) (*PolicyEngine, error) {
+	for i, w := range p.UnlockHours {
+		if err := w.Validate(); err != nil {
+			return nil, fmt.Errorf("unlock hours window %d: %w", i+1, err)
+		}
+	}
+	if p.UnlockRadius > 0 && len(p.UnlockPOIs) == 0 {
+		return nil, fmt.Errorf("unlock radius needs at least one POI")
+	}
+	return &PolicyEngine{policy: p, starts: make(map[string][]time.Time)}, nil
+}
+
+// Audit returns the engine's recent decisions, oldest first.
+func (e *PolicyEngine) Audit() []PolicyDecision {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return slices.Clone(e.audit)
+}
+
+// Authorize checks req against the policy, records the decision and returns
+// a PolicyDeniedError when a rule denies it. Allowed engine starts count
+// toward the daily limit unless they are dry runs; a start that is never
+// accepted by the vehicle is given back before the client returns.
+func (e *PolicyEngine) Authorize(req CommandRequest) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	err := e.evaluate(req)
+	d := PolicyDecision{
+		Time:    req.Time,
+		Vin:     req.Vin,
+		Command: req.Command,
+		Action:  req.Action,
+		Allowed: err == nil,
+		DryRun:  req.DryRun,
+	}
+	if err != nil {
+		d.Rule, d.Reason = err.Rule, err.Message
+	} else if countsAsStart(req) {
+		e.starts[req.Vin] = append(e.starts[req.Vin], req.Time)
+	}
+	if len(e.audit) == policyAuditSize {
+		e.audit = slices.Delete(e.audit, 0, 1)
+	}
+	e.audit = append(e.audit, d)
+	if err != nil {
+		return *err
+	}
+	return nil
+}
+
+// release gives back the engine start an allowed req counted toward the
+// daily limit.
+func (e *PolicyEngine) release(req CommandRequest) {
+	if !countsAsStart(req) {
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if i := slices.IndexFunc(e.starts[req.Vin], req.Time.Equal); i >= 0 {
+		e.starts[req.Vin] = slices.Delete(e.starts[req.Vin], i, i+1)
+	}
+}
+
+// countsAsStart reports whether req counts toward MaxEngineStartsPerDay.
+func countsAsStart(req CommandRequest) bool {
+	return req.Command == CommandEngineStart && req.Action == "execute" && !req.DryRun
+}
+
+type releaseKey struct{}
+
+// withRelease returns a copy of ctx carrying release, which gives back what
+// authorizing a command counted against its limits. It runs at most once.
+func withRelease(ctx context.Context, release func()) context.Context {
+	return context.WithValue(ctx, releaseKey{}, sync.OnceFunc(release))
+}
+
+// releaseCommand undoes the authorization of the command run under ctx. The
+// command paths call it when the command is never accepted: a failed session
+// check, a failed post or a rejected service request.
+func releaseCommand(ctx context.Context) {
+	if release, ok := ctx.Value(releaseKey{}).(func()); ok {
+		release()
+	}
+}
+
+// evaluate returns the first rule req breaks. Callers hold e.mu.
+func (e *PolicyEngine) evaluate(req CommandRequest) *PolicyDeniedError {
+	p := e.policy
+	deny := func(rule PolicyRule, format string, args ...any) *PolicyDeniedError {
+		return &PolicyDeniedError{Command: req.Command, Rule: rule, Message: fmt.Sprintf(format, args...)}
+	}
+
+	if p.DenyStolen && req.Stolen {
+		return deny(RuleStolenVehicle, "vehicle is reported as stolen")
+	}
+	if req.Action != "execute" {
+		return nil
+	}
+
+	switch req.Command {
+	case CommandUnlock:
+		if len(p.UnlockHours) > 0 && !slices.ContainsFunc(p.UnlockHours, func(w CurfewWindow) bool { return w.activeAt(req.Time) }) {
+			return deny(RuleUnlockHours, "unlocking is not allowed at %s", req.Time.Format("Mon 15:04"))
+		}
+		if p.UnlockRadius > 0 {
+			if req.Location == (LatLng{}) {
+				return deny(RuleUnlockLocation, "vehicle location is unknown")
+			}
+			near := slices.ContainsFunc(p.UnlockPOIs, func(poi POI) bool {
+				return DistanceBetween(req.Location, LatLng{Lat: poi.Latitude, Lng: poi.Longitude}) <= p.UnlockRadius
+			})
+			if !near {
+				return deny(RuleUnlockLocation, "vehicle is not within %.0f meters of an allowed place", p.UnlockRadius)
+			}
+		}
+	case CommandEngineStart:
+		if p.MaxEngineStartsPerDay > 0 {
+			y, m, d := req.Time.Date()
+			starts := slices.DeleteFunc(e.starts[req.Vin], func(t time.Time) bool {
+				ty, tm, td := t.In(req.Time.Location()).Date()
+				return ty != y || tm != m || td != d
+			})
+			e.starts[req.Vin] = starts
+			if len(starts) >= p.MaxEngineStartsPerDay {
+				return deny(RuleEngineStartLimit, "engine was already started %d times today", len(starts))
+			}
+		}
+	}
+	return nil
+}
+
+// SetPolicyEngine makes every remote command of the client pass e before it
+// is sent. A nil e removes the policy.
+func (c *Client) SetPolicyEngine(e *PolicyEngine) {
+	c.policy.Store(e)
+}
+
+// newCommandRequest describes a command to reqUrl for the client's policy
+// engine and approver. The action of commands taking an "action" parameter,
+// such as valet mode start and stop, is that parameter.
+func newCommandRequest(vin, reqUrl string, params map[string]string, loc *time.Location) CommandRequest {
+	req := CommandRequest{Vin: vin, Params: redact(params), Time: time.Now().In(loc)}
+	if m := serviceCommandRe.FindStringSubmatch(reqUrl); m != nil {
+		req.Command, req.Action = RemoteCommand(m[1]), m[2]
+	}
+	if action, ok := params["action"]; ok && req.Action == "execute" {
+		req.Action = action
+	}
+	return req
+}
+
+// gated reports whether remote commands of the client need authorizing.
+func (c *Client) gated() bool {
+	return c.policy.Load() != nil || c.approval.Load() != nil
+}
+
+// authorize passes req through the client's policy engine and then its
+// approver, if any. It returns a copy of ctx that releases the policy's
+// count of req (see releaseCommand).
+func (c *Client) authorize(ctx context.Context, req CommandRequest) (context.Context, error) {
+	e := c.policy.Load()
+	if e != nil {
+		if err := e.Authorize(req); err != nil {
+			c.logger.Warn("remote command denied by policy", "vin", req.Vin, "command", req.Command, "error", err.Error())
+			return ctx, err
+		}
+		ctx = withRelease(ctx, func() { e.release(req) })
+	}
+	if g := c.approval.Load(); g != nil {
+		if err := g.approve(ctx, req, c.logger); err != nil {
+			releaseCommand(ctx)
+			return ctx, err
+		}
+	}
+	return ctx, nil
+}
+
+// authorize refuses a command to reqUrl during a PIN lockout and checks it
+// against the client's policy engine and approver. The command runs under
+// the returned copy of ctx.
+func (v *Vehicle) authorize(ctx context.Context, params map[string]string, reqUrl string) (context.Context, error) {
+	if pin, ok := params["pin"]; ok {
+		if err := v.client.checkPIN(pin); err != nil {
+			return ctx, err
+		}
+	}
+	if !v.client.gated() {
+		return ctx, nil
+	}
+	req := newCommandRequest(v.Vin, reqUrl, params, v.Location())
+	v.mu.RLock()
+	req.Location = LatLng{Lat: v.GeoLocation.Latitude, Lng: v.GeoLocation.Longitude}
+	req.Stolen = v.StolenVehicle
+	v.mu.RUnlock()
+	req.DryRun = v.client.dryRunLog(ctx) != nil
+
+	return v.client.authorize(ctx, req)
+}
@@ -0,0 +1,168 @@
+package mysubaru
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestPolicyEngineRules checks each rule against requests at fixed times.
+func TestPolicyEngineRules(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	night := time.Date(2026, 3, 4, 3, 0, 0, 0, loc) // Wednesday 03:00
+	day := time.Date(2026, 3, 4, 9, 30, 0, 0, loc)  // Wednesday 09:30
+	home := LatLng{Lat: 40.7128, Lng: -74.006}
+	away := LatLng{Lat: 40.8, Lng: -74.006}
+
+	e, err := NewPolicyEngine(Policy{
+		UnlockHours:           []CurfewWindow{{Start: "07:00", End: "22:00", Days: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}}},
+		UnlockRadius:          200,
+		UnlockPOIs:            []POI{{Name: "Home", Latitude: home.Lat, Longitude: home.Lng}},
+		MaxEngineStartsPerDay: 2,
+		DenyStolen:            true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		req  CommandRequest
+		rule PolicyRule // empty when allowed
+	}{
+		{"unlock at night", CommandRequest{Command: CommandUnlock, Action: "execute", Location: home, Time: night}, RuleUnlockHours},
+		{"unlock away from home", CommandRequest{Command: CommandUnlock, Action: "execute", Location: away, Time: day}, RuleUnlockLocation},
+		{"unlock with unknown location", CommandRequest{Command: CommandUnlock, Action: "execute", Time: day}, RuleUnlockLocation},
+		{"unlock at home", CommandRequest{Command: CommandUnlock, Action: "execute", Location: home, Time: day}, ""},
+		{"unlock cancel at night", CommandRequest{Command: CommandUnlock, Action: "cancel", Time: night}, ""},
+		{"lock at night", CommandRequest{Command: CommandLock, Action: "execute", Time: night}, ""},
+		{"first engine start", CommandRequest{Command: CommandEngineStart, Action: "execute", Time: day}, ""},
+		{"dry-run engine start", CommandRequest{Command: CommandEngineStart, Action: "execute", Time: day, DryRun: true}, ""},
+		{"second engine start", CommandRequest{Command: CommandEngineStart, Action: "execute", Time: day.Add(time.Hour)}, ""},
+		{"third engine start", CommandRequest{Command: CommandEngineStart, Action: "execute", Time: day.Add(2 * time.Hour)}, RuleEngineStartLimit},
+		{"engine start next day", CommandRequest{Command: CommandEngineStart, Action: "execute", Time: day.Add(24 * time.Hour)}, ""},
+		{"lock while stolen", CommandRequest{Command: CommandLock, Action: "execute", Stolen: true, Time: day}, RuleStolenVehicle},
+	}
+	for _, tt := range tests {
+		err := e.Authorize(tt.req)
+		var denied PolicyDeniedError
+		switch {
+		case tt.rule == "" && err != nil:
+			t.Errorf("%s: expected no error, got %v", tt.name, err)
+		case tt.rule != "" && !errors.As(err, &denied):
+			t.Errorf("%s: expected PolicyDeniedError, got %v", tt.name, err)
+		case tt.rule != "" && denied.Rule != tt.rule:
+			t.Errorf("%s: expected rule %s, got %s", tt.name, tt.rule, denied.Rule)
+		}
+	}
+
+	audit := e.Audit()
+	if len(audit) != len(tests) {
+		t.Fatalf("expected %d audit entries, got %d", len(tests), len(audit))
+	}
+	if d := audit[0]; d.Allowed || d.Rule != RuleUnlockHours || d.Command != CommandUnlock {
+		t.Errorf("expected a denied unlock first, got %+v", d)
+	}
+	if d := audit[3]; !d.Allowed {
+		t.Errorf("expected the unlock at home to be allowed, got %+v", d)
+	}
+}
+
+// TestNewPolicyEngine_Invalid verifies malformed policies are rejected.
+func TestNewPolicyEngine_Invalid(t *testing.T) {
+	if _, err := NewPolicyEngine(Policy{UnlockHours: []CurfewWindow{{Start: "7am", End: "22:00", Days: []time.Weekday{time.Monday}}}}); err == nil {
+		t.Error("expected invalid unlock hours to be rejected")
+	}
+	if _, err := NewPolicyEngine(Policy{UnlockRadius: 100}); err == nil {
+		t.Error("expected an unlock radius without POIs to be rejected")
+	}
+}
+
+// TestPolicyEngine_Commands verifies vehicle commands and Client.RemoteUnlock
+// pass the client's policy engine.
+func TestPolicyEngine_Commands(t *testing.T) {
+	v, sent := setupCountingVehicle(t, false)
+	e, err := NewPolicyEngine(Policy{
+		UnlockRadius: 100,
+		UnlockPOIs:   []POI{{Name: "Office", Latitude: 41.0, Longitude: -73.5}},
+		DenyStolen:   true,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	v.client.SetPolicyEngine(e)
+	ctx := context.Background()
+
+	if _, err := v.Unlock(ctx); !IsPolicyDeniedError(err) {
+		t.Errorf("expected unlock away from the office to be denied, got %v", err)
+	}
+	if err := v.client.RemoteUnlock(ctx, v.Vin); !IsPolicyDeniedError(err) {
+		t.Errorf("expected remote unlock to be denied, got %v", err)
+	}
+	dryCtx, _ := WithDryRun(ctx)
+	if _, err := v.Lock(dryCtx); err != nil {
+		t.Errorf("expected lock to be allowed, got %v", err)
+	}
+
+	v.mu.Lock()
+	v.StolenVehicle = true
+	v.mu.Unlock()
+	if _, err := v.Lock(ctx); !IsPolicyDeniedError(err) {
+		t.Errorf("expected lock of a stolen vehicle to be denied, got %v", err)
+	}
+
+	if n := sent.Load(); n != 0 {
+		t.Errorf("expected no remote service requests, got %d", n)
+	}
+	audit := e.Audit()
+	if len(audit) != 4 || !audit[2].Allowed || !audit[2].DryRun || audit[3].Rule != RuleStolenVehicle {
+		t.Errorf("unexpected audit log: %+v", audit)
+	}
+}
+
+// TestPolicyEngine_UnsentStarts verifies engine starts that are denied by the
+// approver or rejected by the vehicle don't count toward the daily limit.
+func TestPolicyEngine_UnsentStarts(t *testing.T) {
+	v, _ := setupCountingVehicle(t, false, endpointRoute{
+		Method:   http.MethodPost,
+		Path:     apiURLs["API_G2_REMOTE_ENGINE_START"],
+		Response: `{"success":true,"errorCode":null,"dataName":"remoteServiceStatus","data":{"serviceRequestId":null,"success":false,"cancelled":false,"remoteServiceType":"engineStart","remoteServiceState":"finished","errorCode":"SXM40017","errorDescription":null}}`,
+	})
+	e, err := NewPolicyEngine(Policy{MaxEngineStartsPerDay: 1})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	v.client.SetPolicyEngine(e)
+	ctx := context.Background()
+
+	a := NewChannelApprover(1)
+	v.client.SetApprover(a, ApprovalOptions{Commands: []ApprovalTarget{{Command: CommandEngineStart}}})
+	go func() { (<-a.Requests()).Deny() }()
+	if _, err := v.EngineStart(ctx, 10, 0, false); !errors.Is(err, ErrApprovalDenied) {
+		t.Errorf("expected ErrApprovalDenied, got %v", err)
+	}
+	v.client.SetApprover(nil, ApprovalOptions{})
+
+	ch, err := v.EngineStart(ctx, 10, 0, false)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var states []string
+	for s := range ch {
+		states = append(states, s)
+	}
+	if len(states) != 1 || states[0] != "error" {
+		t.Errorf("expected the rejected start to report an error, got %v", states)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if n := len(e.starts[v.Vin]); n != 0 {
+		t.Errorf("expected no engine starts counted, got %d", n)
+	}
+}
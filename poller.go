@@ -0,0 +1,255 @@
+package mysubaru
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultPollActiveInterval is the status and condition interval while the
+	// ignition is on or a remote command is in flight.
+	DefaultPollActiveInterval = time.Minute
+	// DefaultPollNightFactor multiplies the intervals overnight.
+	DefaultPollNightFactor = 4
+	// DefaultPollStagger is the offset between consecutive vehicles' polls.
+	DefaultPollStagger = 15 * time.Second
+	// DefaultMaintenanceBackoff is the first wait once the backend reports
+	// maintenance; it doubles up to DefaultMaxMaintenanceBackoff.
+	DefaultMaintenanceBackoff    = 5 * time.Minute
+	DefaultMaxMaintenanceBackoff = time.Hour
+)
+
+// PollKind is the kind of request a Poller makes.
+type PollKind string
+
+const (
+	PollStatus    PollKind = "status"    // GetVehicleStatus
+	PollCondition PollKind = "condition" // GetVehicleCondition
+	PollHealth    PollKind = "health"    // GetVehicleHealth
+)
+
+var pollKinds = []PollKind{PollStatus, PollCondition, PollHealth}
+
+// PollResult is the outcome of one poll. During maintenance the poller reports
+// ErrMaintenance with an empty Vin and Kind at each availability check.
+type PollResult struct {
+	Vin  string
+	Kind PollKind
+	Time time.Time
+	Err  error
+}
+
+// PollerOptions configures a Poller. Zero values use the defaults; a negative
+// interval turns that kind of poll off.
+type PollerOptions struct {
+	StatusInterval     time.Duration // default DEFAULT_FETCH_INTERVAL
+	ConditionInterval  time.Duration // default DEFAULT_FETCH_INTERVAL
+	HealthInterval     time.Duration // default DEFAULT_UPDATE_INTERVAL
+	ActiveInterval     time.Duration // default DefaultPollActiveInterval
+	Night              CurfewWindow  // in the vehicle's time zone, default 23:00-06:00 daily
+	NightFactor        int           // default DefaultPollNightFactor
+	Stagger            time.Duration // default DefaultPollStagger
+	MaintenanceBackoff time.Duration // default DefaultMaintenanceBackoff
+	MaxBackoff         time.Duration // default DefaultMaxMaintenanceBackoff
+}
+
+// Poller keeps vehicles' status, condition and health fresh. It polls each
+// vehicle on its own intervals, faster while the ignition is on or a remote
+// command is in flight and slower overnight, and staggers the vehicles so
+// their requests don't arrive together. When a poll fails and GetAppStatus
+// reports the backend in maintenance, it stops polling and checks the app
+// status again with exponential backoff until the backend is back.
+type Poller struct {
+	opts    PollerOptions
+	targets []*pollTarget
+	now     func() time.Time
+}
+
+// pollTarget is a vehicle with the time of its first and latest polls.
+type pollTarget struct {
+	v     *Vehicle
+	first time.Time
+	last  map[PollKind]time.Time
+}
+
+// NewPoller returns a poller for vehicles.
+func NewPoller(opts PollerOptions, vehicles ...*Vehicle) (*Poller, error) {
+	if len(vehicles) == 0 {
+		return nil, errors.New("poller needs at least one vehicle")
+	}
+	opts.StatusInterval = pollDefault(opts.StatusInterval, DEFAULT_FETCH_INTERVAL*time.Second)
+	opts.ConditionInterval = pollDefault(opts.ConditionInterval, DEFAULT_FETCH_INTERVAL*time.Second)
+	opts.HealthInterval = pollDefault(opts.HealthInterval, DEFAULT_UPDATE_INTERVAL*time.Second)
+	if opts.StatusInterval < 0 && opts.ConditionInterval < 0 && opts.HealthInterval < 0 {
+		return nil, errors.New("poller has every kind of poll turned off")
+	}
+	opts.ActiveInterval = pollDefault(opts.ActiveInterval, DefaultPollActiveInterval)
+	if opts.Night.Start == "" && opts.Night.End == "" {
+		opts.Night = CurfewWindow{Start: "23:00", End: "06:00", Days: []time.Weekday{
+			time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday,
+		}}
+	}
+	if err := opts.Night.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid night window: %w", err)
+	}
+	if opts.NightFactor <= 0 {
+		opts.NightFactor = DefaultPollNightFactor
+	}
+	opts.Stagger = pollDefault(opts.Stagger, DefaultPollStagger)
+	opts.MaintenanceBackoff = pollDefault(opts.MaintenanceBackoff, DefaultMaintenanceBackoff)
+	opts.MaxBackoff = max(pollDefault(opts.MaxBackoff, DefaultMaxMaintenanceBackoff), opts.MaintenanceBackoff)
+
+	p := &Poller{opts: opts, now: time.Now}
+	for _, v := range vehicles {
+		p.targets = append(p.targets, &pollTarget{v: v, last: map[PollKind]time.Time{}})
+	}
+	return p, nil
+}
+
+// pollDefault returns d, or def when d is zero.
+func pollDefault(d, def time.Duration) time.Duration {
+	if d == 0 {
+		return def
+	}
+	return d
+}
+
+// Run polls until ctx is done and reports each poll on the returned channel,
+// which is closed when Run stops. The first vehicle is polled at once.
+func (p *Poller) Run(ctx context.Context) <-chan PollResult {
+	ch := make(chan PollResult, 8)
+	go func() {
+		defer close(ch)
+		send := func(r PollResult) bool {
+			select {
+			case ch <- r:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		start := p.now()
+		for i, t := range p.targets {
+			t.first = start.Add(time.Duration(i) * p.opts.Stagger)
+		}
+		var down *Client // the client whose backend is in maintenance
+		backoff := p.opts.MaintenanceBackoff
+		for {
+			if down != nil {
+				if sleepCtx(ctx, backoff) != nil {
+					return
+				}
+				if up, err := down.GetAppStatus(ctx); err == nil && !up {
+					backoff = min(2*backoff, p.opts.MaxBackoff)
+					if !send(PollResult{Time: p.now(), Err: ErrMaintenance}) {
+						return
+					}
+					continue
+				}
+				down, backoff = nil, p.opts.MaintenanceBackoff
+			}
+
+			now := p.now()
+			t, kind, due := p.next(now)
+			if wait := due.Sub(now); wait > 0 {
+				// Wake up at least every active interval so a vehicle that
+				// became active is polled sooner than it was scheduled.
+				if sleepCtx(ctx, min(wait, p.opts.ActiveInterval)) != nil {
+					return
+				}
+				continue
+			}
+
+			err := p.poll(ctx, t.v, kind)
+			t.last[kind] = p.now()
+			if !send(PollResult{Vin: t.v.Vin, Kind: kind, Time: t.last[kind], Err: err}) {
+				return
+			}
+			if err != nil {
+				if up, err := t.v.client.GetAppStatus(ctx); err == nil && !up {
+					t.v.client.logger.Warn("MySubaru API in maintenance, pausing polls", "retry", backoff)
+					down = t.v.client
+					if !send(PollResult{Time: p.now(), Err: ErrMaintenance}) {
+						return
+					}
+				}
+			}
+		}
+	}()
+	return ch
+}
+
+// next returns the poll due first and when it is due. Ties go to the earlier
+// vehicle and to status before condition before health.
+func (p *Poller) next(now time.Time) (*pollTarget, PollKind, time.Time) {
+	var (
+		target *pollTarget
+		kind   PollKind
+		due    time.Time
+	)
+	for _, t := range p.targets {
+		for _, k := range pollKinds {
+			interval := p.interval(t.v, k, now)
+			if interval < 0 {
+				continue
+			}
+			at := t.first
+			if last, ok := t.last[k]; ok {
+				at = last.Add(interval)
+			}
+			if target == nil || at.Before(due) {
+				target, kind, due = t, k, at
+			}
+		}
+	}
+	return target, kind, due
+}
+
+// interval returns how long after its last poll of kind v is due again at
+// now, or a negative duration when that kind of poll is off.
+func (p *Poller) interval(v *Vehicle, kind PollKind, now time.Time) time.Duration {
+	var d time.Duration
+	switch kind {
+	case PollStatus:
+		d = p.opts.StatusInterval
+	case PollCondition:
+		d = p.opts.ConditionInterval
+	case PollHealth:
+		d = p.opts.HealthInterval
+	}
+	if d < 0 {
+		return d
+	}
+	if kind != PollHealth && v.active() {
+		return min(d, p.opts.ActiveInterval)
+	}
+	if p.opts.Night.activeAt(now.In(v.Location())) {
+		return d * time.Duration(p.opts.NightFactor)
+	}
+	return d
+}
+
+// poll makes one request of kind for v.
+func (p *Poller) poll(ctx context.Context, v *Vehicle, kind PollKind) error {
+	switch kind {
+	case PollCondition:
+		return v.GetVehicleCondition(ctx)
+	case PollHealth:
+		return v.GetVehicleHealth(ctx)
+	}
+	return v.GetVehicleStatus(ctx)
+}
+
+// active reports whether v's ignition is on or one of its remote commands is
+// in flight.
+func (v *Vehicle) active() bool {
+	if v.commandsInFlight.Load() > 0 {
+		return true
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.EngineState == IgnitionOn
+}
@@ -0,0 +1,135 @@
+package mysubaru
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestPollerInterval verifies intervals speed up for active vehicles and
+// slow down overnight in the vehicle's time zone.
+func TestPollerInterval(t *testing.T) {
+	v := &Vehicle{TimeZone: "America/New_York", EngineState: IgnitionOff}
+	p, err := NewPoller(PollerOptions{ConditionInterval: -1}, v)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	loc, _ := time.LoadLocation("America/New_York")
+	day := time.Date(2026, 3, 4, 12, 0, 0, 0, loc)
+	night := time.Date(2026, 3, 5, 2, 0, 0, 0, loc)
+
+	tests := []struct {
+		name string
+		kind PollKind
+		at   time.Time
+		want time.Duration
+	}{
+		{"status by day", PollStatus, day, 5 * time.Minute},
+		{"status overnight", PollStatus, night.UTC(), 20 * time.Minute},
+		{"health by day", PollHealth, day, 2 * time.Hour},
+		{"condition turned off", PollCondition, day, -1},
+	}
+	for _, tt := range tests {
+		if got := p.interval(v, tt.kind, tt.at); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	v.commandsInFlight.Add(1)
+	if got := p.interval(v, PollStatus, night); got != DefaultPollActiveInterval {
+		t.Errorf("expected the active interval with a command in flight, got %v", got)
+	}
+	v.commandsInFlight.Add(-1)
+	v.EngineState = IgnitionOn
+	if got := p.interval(v, PollStatus, day); got != DefaultPollActiveInterval {
+		t.Errorf("expected the active interval with the ignition on, got %v", got)
+	}
+	if got := p.interval(v, PollHealth, day); got != 2*time.Hour {
+		t.Errorf("expected health polls not to speed up, got %v", got)
+	}
+}
+
+// TestPollerNext verifies vehicles are staggered and polls come due in order.
+func TestPollerNext(t *testing.T) {
+	a, b := &Vehicle{Vin: "A"}, &Vehicle{Vin: "B"}
+	p, err := NewPoller(PollerOptions{HealthInterval: -1, Night: CurfewWindow{Start: "00:00", End: "00:01", Days: []time.Weekday{time.Sunday}}}, a, b)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	start := time.Date(2026, 3, 4, 12, 0, 0, 0, time.Local)
+	p.targets[0].first, p.targets[1].first = start, start.Add(DefaultPollStagger)
+
+	if tg, kind, due := p.next(start); tg.v != a || kind != PollStatus || !due.Equal(start) {
+		t.Errorf("expected A's status first, got %s %s at %v", tg.v.Vin, kind, due)
+	}
+	p.targets[0].last[PollStatus] = start
+	p.targets[0].last[PollCondition] = start
+	if tg, kind, due := p.next(start); tg.v != b || kind != PollStatus || !due.Equal(start.Add(DefaultPollStagger)) {
+		t.Errorf("expected B's status after the stagger, got %s %s at %v", tg.v.Vin, kind, due)
+	}
+}
+
+// TestNewPoller_Invalid verifies unusable options are rejected.
+func TestNewPoller_Invalid(t *testing.T) {
+	if _, err := NewPoller(PollerOptions{}); err == nil {
+		t.Error("expected a poller without vehicles to be rejected")
+	}
+	if _, err := NewPoller(PollerOptions{StatusInterval: -1, ConditionInterval: -1, HealthInterval: -1}, &Vehicle{}); err == nil {
+		t.Error("expected a poller without polls to be rejected")
+	}
+	if _, err := NewPoller(PollerOptions{Night: CurfewWindow{Start: "late", End: "06:00"}}, &Vehicle{}); err == nil {
+		t.Error("expected an invalid night window to be rejected")
+	}
+}
+
+// TestPollerRun verifies Run polls the vehicle and reports the results.
+func TestPollerRun(t *testing.T) {
+	v, _ := setupCountingVehicle(t, false)
+	p, err := NewPoller(PollerOptions{StatusInterval: 10 * time.Millisecond, ConditionInterval: -1, HealthInterval: -1, ActiveInterval: 10 * time.Millisecond, NightFactor: 1}, v)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results := p.Run(ctx)
+	for range 2 {
+		r := <-results
+		if r.Err != nil || r.Vin != v.Vin || r.Kind != PollStatus {
+			t.Errorf("unexpected poll result: %+v", r)
+		}
+	}
+	cancel()
+	for range results {
+	}
+}
+
+// TestPollerMaintenance verifies a failed poll during maintenance pauses
+// polling until the backend is back.
+func TestPollerMaintenance(t *testing.T) {
+	v, _ := setupCountingVehicle(t, false,
+		endpointRoute{Method: http.MethodGet, Path: apiURLs["API_VEHICLE_STATUS"], Response: `{"success":false,"errorCode":"vehicleNotInAccount","dataName":null,"data":null}`},
+		endpointRoute{Method: http.MethodGet, Path: apiURLs["API_APP_STATUS"], Response: `{"success":false,"errorCode":"SERVER_MAINTENANCE","dataName":null,"data":null}`},
+	)
+	p, err := NewPoller(PollerOptions{ConditionInterval: -1, HealthInterval: -1, MaintenanceBackoff: 10 * time.Millisecond}, v)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	results := p.Run(ctx)
+	if r := <-results; r.Err == nil || r.Kind != PollStatus {
+		t.Errorf("expected a failed status poll, got %+v", r)
+	}
+	for range 2 {
+		if r := <-results; !errors.Is(r.Err, ErrMaintenance) || r.Vin != "" {
+			t.Errorf("expected a maintenance result, got %+v", r)
+		}
+	}
+	cancel()
+	for range results {
+	}
+}
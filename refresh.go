@@ -0,0 +1,143 @@
+package mysubaru
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// =============================================================================
+// Status Refresh
+// =============================================================================
+
+// RefreshStatus asks the vehicle to report its current state, waits until it
+// has, and then updates Doors, Windows, Tires, fuel, odometer and location in
+// one step. GetVehicleStatus only returns what the cloud last cached, which can
+// be hours old; RefreshStatus wakes the telematics unit, so use it sparingly.
+func (v *Vehicle) RefreshStatus(ctx context.Context) error {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return err
+	}
+	params := map[string]string{
+		"delay": "0",
+		"vin":   v.Vin,
+		"pin":   pin,
+	}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityStatusRefresh, "API_G2_VEHICLE_STATUS_REFRESH")
+	if err != nil {
+		return err
+	}
+
+	sr, err := v.sendServiceRequest(ctx, params, reqUrl)
+	if err != nil {
+		return err
+	}
+	vc, err := v.waitForCondition(ctx, sr, pollingUrl)
+	if err != nil {
+		return err
+	}
+
+	// The cloud cache now holds the refreshed report, including the location
+	// and fuel readings the condition result lacks.
+	vs, err := v.fetchVehicleStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	defer v.trackChanges()()
+	// Status readings win over the condition's where both report a value,
+	// except tires, where the later reading wins.
+	if vc != nil {
+		v.updateVehicleFromCondition(vc)
+	}
+	v.applyVehicleStatus(vs, readingTime(vs.EventDate.Time))
+	if vc != nil {
+		v.updateOutsideTemp(vc.OutsideTemp)
+		at := vc.updatedAt()
+		for _, p := range []struct {
+			name  string
+			value string
+		}{
+			{"DoorBootPosition", vc.DoorBootPosition},
+			{"DoorEngineHoodPosition", vc.DoorEngineHoodPosition},
+			{"DoorFrontLeftPosition", vc.DoorFrontLeftPosition},
+			{"DoorFrontRightPosition", vc.DoorFrontRightPosition},
+			{"DoorRearLeftPosition", vc.DoorRearLeftPosition},
+			{"DoorRearRightPosition", vc.DoorRearRightPosition},
+			{"WindowFrontLeftStatus", vc.WindowFrontLeftStatus},
+			{"WindowFrontRightStatus", vc.WindowFrontRightStatus},
+			{"WindowRearLeftStatus", vc.WindowRearLeftStatus},
+			{"WindowRearRightStatus", vc.WindowRearRightStatus},
+			{"WindowSunroofStatus", vc.WindowSunroofStatus},
+		} {
+			if !isBadValue(p.value) {
+				v.parseParts(p.name, p.value, at)
+			}
+		}
+	}
+	return nil
+}
+
+// waitForCondition polls the condition status endpoint for a refresh request
+// until the vehicle has answered, returning the reported condition (nil when
+// the finished request carries no result).
+func (v *Vehicle) waitForCondition(ctx context.Context, sr *ServiceRequest, pollingUrl string) (*VehicleCondition, error) {
+	id := sr.ServiceRequestID
+
+	for attempt := 1; ; attempt++ {
+		if done, vc, err := v.refreshResult(sr); done {
+			return vc, err
+		}
+		if attempt >= MaxServiceRequestAttempts {
+			v.client.logger.Error("maximum attempts reached for vehicle status refresh", "vin", v.Vin, "attempts", attempt)
+			return nil, ErrRefreshTimeout
+		}
+		if attempt > 1 {
+			if err := sleepCtx(ctx, ServiceRequestPollDelay); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := v.execute(ctx, GET, pollingUrl, map[string]string{"serviceRequestId": id}, false)
+		if err != nil {
+			v.client.logger.Error("error while polling vehicle status refresh", "vin", v.Vin, "error", err.Error())
+			return nil, err
+		}
+		next, ok := v.parseServiceRequest(resp.Data)
+		if !ok {
+			return nil, errors.New("error while parsing service request json")
+		}
+		v.client.logger.Debug("vehicle status refresh in progress", "id", id, "state", next.RemoteServiceState)
+		sr = &next
+	}
+}
+
+// refreshResult reports whether a refresh request has ended and, if so, the
+// condition it returned or the reason it failed.
+func (v *Vehicle) refreshResult(sr *ServiceRequest) (bool, *VehicleCondition, error) {
+	if !sr.Success && sr.ErrorCode != "" {
+		v.client.logger.Error("vehicle status refresh failed", "vin", v.Vin, "errorCode", sr.ErrorCode)
+		return true, nil, v.client.parseServiceError(sr.ErrorCode, sr.ErrorDescription)
+	}
+	if sr.Cancelled {
+		return true, nil, errors.New("vehicle status refresh was cancelled")
+	}
+	if sr.RemoteServiceState != "finished" {
+		return false, nil, nil
+	}
+	if !sr.Success {
+		return true, nil, errors.New("vehicle status refresh finished without success")
+	}
+	if isJSONStringOrNull(sr.Result) {
+		return true, nil, nil
+	}
+	var vc VehicleCondition
+	if err := json.Unmarshal(sr.Result, &vc); err != nil {
+		v.client.logger.Error("error while parsing json", "request", "RefreshStatus", "error", err.Error())
+		return true, nil, err
+	}
+	return true, &vc, nil
+}
@@ -0,0 +1,124 @@
+package mysubaru
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testRefreshStartedResponse   = `{"success":true,"errorCode":null,"dataName":"remoteServiceStatus","data":{"serviceRequestId":"1HGCM82633A004352_1751747301812_25_@NGTP","success":false,"cancelled":false,"remoteServiceType":"vehicleStatus","remoteServiceState":"started","subState":null,"errorCode":null,"result":null,"updateTime":null,"vin":"1HGCM82633A004352","errorDescription":null}}`
+	testRefreshConditionResponse = `{"success":true,"errorCode":null,"dataName":"remoteServiceStatus","data":{"serviceRequestId":"1HGCM82633A004352_1751747301812_25_@NGTP","success":true,"cancelled":false,"remoteServiceType":"condition","remoteServiceState":"finished","subState":null,"errorCode":null,"result":{"odometer":31694,"odometerUnit":"MILES","remainingFuelPercent":"90","outsideTemp":"18.5","doorBootPosition":"CLOSED","doorEngineHoodPosition":"CLOSED","doorFrontLeftPosition":"OPEN","doorFrontRightPosition":"CLOSED","doorRearLeftPosition":"CLOSED","doorRearRightPosition":"CLOSED","windowFrontLeftStatus":"CLOSE","windowFrontRightStatus":"CLOSE","windowRearLeftStatus":"CLOSE","windowRearRightStatus":"CLOSE","windowSunroofStatus":"VENTED","vehicleStateType":"IGNITION_OFF"},"updateTime":null,"vin":"1HGCM82633A004352","errorDescription":null}}`
+)
+
+// setupRefreshVehicle starts a mock server answering the refresh command and
+// the condition status poll, plus any extra routes, and returns an
+// authenticated vehicle.
+func setupRefreshVehicle(t *testing.T, conditionResponse string, extra ...endpointRoute) *Vehicle {
+	t.Helper()
+	routes := append(append(extra, standardTestRoutes()...),
+		endpointRoute{Method: http.MethodPost, Path: apiURLs["API_G2_VEHICLE_STATUS_REFRESH"], Response: testRefreshStartedResponse},
+		endpointRoute{Method: http.MethodGet, Path: apiURLs["API_G2_VEHICLE_CONDITION_STATUS"], Response: conditionResponse},
+	)
+	ts := mockServerWithRoutes(t, routes)
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	msc, err := New(mockConfig(t))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ok, _, err := msc.Authenticate(context.Background()); !ok || err != nil {
+		t.Fatalf("expected authentication to succeed, got ok=%v, err=%v", ok, err)
+	}
+	v, err := msc.GetVehicleByVin(context.Background(), "1HGCM82633A004352")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return v
+}
+
+// TestRefreshStatus verifies a refresh applies the polled condition and the
+// refreshed status together and marks each reading fresh.
+func TestRefreshStatus(t *testing.T) {
+	// The refresh leaves a freshly reported status in the cloud cache.
+	before := time.Now().Truncate(time.Millisecond)
+	status := strings.Replace(testVehicleStatusResponse, `"eventDate":1751742945000`, fmt.Sprintf(`"eventDate":%d`, before.UnixMilli()), 1)
+	v := setupRefreshVehicle(t, testRefreshConditionResponse,
+		endpointRoute{Method: http.MethodGet, Path: apiURLs["API_VEHICLE_STATUS"], Response: status})
+
+	if err := v.RefreshStatus(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if d := v.Doors["door_front_left"]; d.Status != "OPEN" || d.Lock != "LOCKED" || d.Updated.Before(before) {
+		t.Errorf("expected fresh open and locked front left door, got %+v", d)
+	}
+	if w := v.Windows["window_sunroof"]; w.Status != "VENTED" || w.Updated.Before(before) {
+		t.Errorf("expected fresh vented sunroof, got %+v", w)
+	}
+	if tire := v.Tires["tire_front_left"]; tire.PressurePsi != 36 || tire.Updated.Before(before) {
+		t.Errorf("expected fresh 36 psi front left tire, got %+v", tire)
+	}
+	if v.Odometer.Miles != 31694 || v.Odometer.Updated.Before(before) {
+		t.Errorf("expected fresh odometer of 31694 miles, got %+v", v.Odometer)
+	}
+	if v.DistanceToEmpty.Percentage != 90 || v.DistanceToEmpty.Updated.Before(before) {
+		t.Errorf("expected fresh fuel level of 90%%, got %+v", v.DistanceToEmpty)
+	}
+	if v.GeoLocation.Latitude != 40.700153 || v.GeoLocation.Updated.Before(before) {
+		t.Errorf("expected fresh location, got %+v", v.GeoLocation)
+	}
+	if !v.OutsideTemp.Valid || v.OutsideTemp.Celsius != 18.5 {
+		t.Errorf("expected outside temperature of 18.5°C, got %+v", v.OutsideTemp)
+	}
+}
+
+// TestRefreshStatus_StaleStatus verifies the condition's tire readings, which
+// the vehicle just sent, are kept over older ones in the cached status.
+func TestRefreshStatus_StaleStatus(t *testing.T) {
+	taken := time.Now().Add(-3 * time.Hour).Truncate(time.Millisecond)
+	fresh := time.Now().Add(-time.Minute).Truncate(time.Second)
+	status := strings.Replace(testVehicleStatusResponse, `"eventDate":1751742945000`, fmt.Sprintf(`"eventDate":%d`, taken.UnixMilli()), 1)
+	condition := strings.Replace(testRefreshConditionResponse, `"odometer":31694,`,
+		`"odometer":31694,"tirePressureFrontLeft":33,"tirePressureFrontLeftUnit":"PSI","lastUpdatedTime":"`+fresh.UTC().Format("2006-01-02T15:04:05.000-0700")+`",`, 1)
+	v := setupRefreshVehicle(t, condition,
+		endpointRoute{Method: http.MethodGet, Path: apiURLs["API_VEHICLE_STATUS"], Response: status})
+
+	if err := v.RefreshStatus(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if tire := v.Tires["tire_front_left"]; tire.PressurePsi != 33 || !tire.Updated.Equal(fresh) {
+		t.Errorf("expected the condition's 33 psi front left tire, got %+v", tire)
+	}
+	// Loading the vehicle already recorded the cached reading at its own time.
+	if h := v.TirePressureHistory("tire_front_left"); len(h) != 2 || !h[0].Time.Equal(taken) || !h[1].Time.Equal(fresh) {
+		t.Errorf("expected the cached and condition readings at their own times, got %+v", h)
+	}
+	if tire := v.Tires["tire_rear_left"]; tire.PressurePsi != 35 || !tire.Updated.Equal(taken) {
+		t.Errorf("expected the status's rear left tire at its event date, got %+v", tire)
+	}
+	if !v.Odometer.Updated.Equal(taken) {
+		t.Errorf("expected the odometer stamped with the status event date %v, got %v", taken, v.Odometer.Updated)
+	}
+}
+
+// TestRefreshStatus_Failed verifies a refresh the vehicle rejects is reported
+// and leaves the cached state untouched.
+func TestRefreshStatus_Failed(t *testing.T) {
+	v := setupRefreshVehicle(t, `{"success":true,"errorCode":null,"dataName":"remoteServiceStatus","data":{"serviceRequestId":"1HGCM82633A004352_1751747301812_25_@NGTP","success":false,"cancelled":false,"remoteServiceType":"condition","remoteServiceState":"finished","subState":null,"errorCode":"NegativeAcknowledge_runningOnBackupBattery","result":null,"updateTime":null,"vin":"1HGCM82633A004352","errorDescription":null}}`)
+
+	cached := v.Odometer.Updated
+
+	err := v.RefreshStatus(context.Background())
+	if !errors.Is(err, ErrBackupBattery) {
+		t.Fatalf("expected ErrBackupBattery, got %v", err)
+	}
+	if !v.Odometer.Updated.Equal(cached) {
+		t.Errorf("expected odometer to keep its cached timestamp %v, got %v", cached, v.Odometer.Updated)
+	}
+}
@@ -0,0 +1,537 @@
+package mysubaru
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultScheduleCheckInterval = 30 * time.Second
+	DefaultScheduleRunMinutes    = 10
+
+	// scheduleDateLayout is the layout of exclusion dates.
+	scheduleDateLayout = "2006-01-02"
+)
+
+// MissedRunPolicy decides what the scheduler does with a run whose time passed
+// while it wasn't running (process down, machine asleep).
+type MissedRunPolicy int
+
+const (
+	// MissedRunSkip reports the missed run and waits for the next one.
+	MissedRunSkip MissedRunPolicy = iota
+	// MissedRunCatchUp starts the engine once, as soon as the miss is noticed,
+	// if it is still within SchedulerOptions.CatchUpWindow.
+	MissedRunCatchUp
+)
+
+// ScheduleStatus is the result of a scheduled run.
+type ScheduleStatus string
+
+const (
+	ScheduleStarted  ScheduleStatus = "started"  // the engine start command was sent and completed
+	ScheduleFailed   ScheduleStatus = "failed"   // the engine start command failed
+	ScheduleExcluded ScheduleStatus = "excluded" // the run fell on an excluded date
+	ScheduleMissed   ScheduleStatus = "missed"   // the run was missed and skipped by policy
+)
+
+// ScheduleRule is a recurring remote start. A rule is either a five-field cron
+// expression ("minute hour day-of-month month day-of-week") or a time of day
+// (At, "HH:MM") with optional Weekdays and Months. Times are interpreted in the
+// vehicle's time zone.
+type ScheduleRule struct {
+	ID         string         `json:"id"`
+	Cron       string         `json:"cron,omitempty"`       // "40 7 * 1,2,3,11,12 1-5"
+	At         string         `json:"at,omitempty"`         // "07:40"
+	Weekdays   []time.Weekday `json:"weekdays,omitempty"`   // empty means every day
+	Months     []time.Month   `json:"months,omitempty"`     // empty means every month
+	Profile    string         `json:"profile,omitempty"`    // climate profile name, empty for defaults
+	RunMinutes int            `json:"runMinutes,omitempty"` // 0 means DefaultScheduleRunMinutes
+	Disabled   bool           `json:"disabled,omitempty"`
+	LastRun    time.Time      `json:"lastRun,omitzero"` // last handled run, maintained by the scheduler
+}
+
+// ScheduleOutcome reports what happened to one scheduled run.
+type ScheduleOutcome struct {
+	RuleID     string
+	Scheduled  time.Time // when the run was due
+	Status     ScheduleStatus
+	FinalState string // last remote service state for started runs
+	Err        error
+}
+
+// ScheduleStore persists the scheduler's rules and exclusion dates.
+type ScheduleStore interface {
+	Load() (ScheduleState, error)
+	Save(ScheduleState) error
+}
+
+// ScheduleState is the persisted scheduler state.
+type ScheduleState struct {
+	Rules      []ScheduleRule `json:"rules"`
+	Exclusions []string       `json:"exclusions,omitempty"` // "2006-01-02" in the vehicle's time zone
+}
+
+// FileScheduleStore stores the scheduler state as JSON in a file. A missing
+// file loads as an empty state.
+type FileScheduleStore struct {
+	Path string
+}
+
+// Load reads the state from the file.
+func (f FileScheduleStore) Load() (ScheduleState, error) {
+	var st ScheduleState
+	b, err := os.ReadFile(f.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return st, err
+	}
+	if err := json.Unmarshal(b, &st); err != nil {
+		return st, fmt.Errorf("failed to parse schedule file %s: %w", f.Path, err)
+	}
+	return st, nil
+}
+
+// Save writes the state to the file, replacing it atomically.
+func (f FileScheduleStore) Save(st ScheduleState) error {
+	b, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := f.Path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, f.Path)
+}
+
+// SchedulerOptions configures a Scheduler. Zero values use the defaults.
+type SchedulerOptions struct {
+	Location        *time.Location  // overrides the vehicle's time zone
+	MissedRunPolicy MissedRunPolicy // default MissedRunSkip
+	CatchUpWindow   time.Duration   // max lateness for MissedRunCatchUp, 0 means no limit
+	CheckInterval   time.Duration   // how often rules are checked, default 30s
+	MissedGrace     time.Duration   // lateness after which a run counts as missed, default 2*CheckInterval
+}
+
+// engineStartFunc matches Vehicle.EngineStartWithProfile.
+type engineStartFunc func(ctx context.Context, run, delay int, horn bool, profileName string) (chan string, error)
+
+// Scheduler runs recurring remote starts through Vehicle.EngineStartWithProfile.
+type Scheduler struct {
+	store ScheduleStore
+	opts  SchedulerOptions
+	loc   *time.Location
+	start engineStartFunc
+	now   func() time.Time
+
+	mu      sync.Mutex
+	state   ScheduleState
+	created time.Time
+}
+
+// NewScheduler creates a scheduler for v and loads its state from store. A nil
+// store keeps the state in memory only.
+func NewScheduler(v *Vehicle, store ScheduleStore, opts SchedulerOptions) (*Scheduler, error) {
+	if opts.CheckInterval <= 0 {
+		opts.CheckInterval = DefaultScheduleCheckInterval
+	}
+	if opts.MissedGrace <= 0 {
+		opts.MissedGrace = 2 * opts.CheckInterval
+	}
+	loc := opts.Location
+	if loc == nil {
+		loc = v.Location()
+	}
+
+	s := &Scheduler{
+		store: store,
+		opts:  opts,
+		loc:   loc,
+		start: v.EngineStartWithProfile,
+		now:   time.Now,
+	}
+	s.created = s.now()
+	if store != nil {
+		st, err := store.Load()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range st.Rules {
+			if err := r.validate(); err != nil {
+				return nil, fmt.Errorf("invalid stored schedule rule %q: %w", r.ID, err)
+			}
+		}
+		s.state = st
+	}
+	return s, nil
+}
+
+// AddRule validates and adds a rule, replacing any rule with the same ID.
+func (s *Scheduler) AddRule(rule ScheduleRule) error {
+	if err := rule.validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if i := s.ruleIndex(rule.ID); i >= 0 {
+		s.state.Rules[i] = rule
+	} else {
+		s.state.Rules = append(s.state.Rules, rule)
+	}
+	return s.save()
+}
+
+// RemoveRule removes the rule with the given ID.
+func (s *Scheduler) RemoveRule(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.ruleIndex(id)
+	if i < 0 {
+		return fmt.Errorf("schedule rule %q not found", id)
+	}
+	s.state.Rules = slices.Delete(s.state.Rules, i, i+1)
+	return s.save()
+}
+
+// Rules returns a copy of the scheduler's rules.
+func (s *Scheduler) Rules() []ScheduleRule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.state.Rules)
+}
+
+// Exclude adds dates ("2006-01-02", vehicle time zone) on which no rule runs,
+// e.g. holidays.
+func (s *Scheduler) Exclude(dates ...string) error {
+	for _, d := range dates {
+		if _, err := time.Parse(scheduleDateLayout, d); err != nil {
+			return fmt.Errorf("exclusion date must be in YYYY-MM-DD format, got %s", d)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, d := range dates {
+		if !slices.Contains(s.state.Exclusions, d) {
+			s.state.Exclusions = append(s.state.Exclusions, d)
+		}
+	}
+	slices.Sort(s.state.Exclusions)
+	return s.save()
+}
+
+// RemoveExclusion removes an exclusion date.
+func (s *Scheduler) RemoveExclusion(date string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.Exclusions = slices.DeleteFunc(s.state.Exclusions, func(d string) bool { return d == date })
+	return s.save()
+}
+
+// NextRun returns the next time the rule with the given ID is due, skipping
+// excluded dates. It returns the zero time for a disabled rule.
+func (s *Scheduler) NextRun(id string) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	i := s.ruleIndex(id)
+	if i < 0 {
+		return time.Time{}, fmt.Errorf("schedule rule %q not found", id)
+	}
+	r := s.state.Rules[i]
+	if r.Disabled {
+		return time.Time{}, nil
+	}
+	spec, _ := r.spec()
+	next := spec.next(s.now(), s.loc)
+	for !next.IsZero() && s.excluded(next) {
+		next = spec.next(next, s.loc)
+	}
+	return next, nil
+}
+
+// Run checks the rules every CheckInterval until ctx is cancelled, starting the
+// engine for each due run. Outcomes are sent on the returned channel, which is
+// closed when Run stops.
+func (s *Scheduler) Run(ctx context.Context) <-chan ScheduleOutcome {
+	ch := make(chan ScheduleOutcome, 16)
+	go func() {
+		defer close(ch)
+		for {
+			for _, o := range s.check(ctx, s.now()) {
+				select {
+				case ch <- o:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if sleepCtx(ctx, s.opts.CheckInterval) != nil {
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// check handles every run that is due at now and returns the outcomes. Runs
+// that piled up for a rule while the scheduler was down collapse into the
+// latest one, so a rule never starts the engine more than once per check.
+func (s *Scheduler) check(ctx context.Context, now time.Time) []ScheduleOutcome {
+	s.mu.Lock()
+	type dueRun struct {
+		rule ScheduleRule
+		at   time.Time
+	}
+	var due []dueRun
+	var outcomes []ScheduleOutcome
+	for i := range s.state.Rules {
+		r := &s.state.Rules[i]
+		if r.Disabled {
+			continue
+		}
+		spec, _ := r.spec()
+		from := r.LastRun
+		if from.IsZero() {
+			// never run: only runs after the scheduler was created count
+			from = s.created
+		}
+		at := spec.next(from, s.loc)
+		if at.IsZero() || at.After(now) {
+			continue
+		}
+		for n := spec.next(at, s.loc); !n.IsZero() && !n.After(now); n = spec.next(n, s.loc) {
+			at = n
+		}
+		r.LastRun = at
+
+		switch late := now.Sub(at); {
+		case s.excluded(at):
+			outcomes = append(outcomes, ScheduleOutcome{RuleID: r.ID, Scheduled: at, Status: ScheduleExcluded})
+		case late > s.opts.MissedGrace &&
+			(s.opts.MissedRunPolicy != MissedRunCatchUp || (s.opts.CatchUpWindow > 0 && late > s.opts.CatchUpWindow)):
+			outcomes = append(outcomes, ScheduleOutcome{RuleID: r.ID, Scheduled: at, Status: ScheduleMissed})
+		default:
+			due = append(due, dueRun{rule: *r, at: at})
+		}
+	}
+	if err := s.save(); err != nil {
+		outcomes = append(outcomes, ScheduleOutcome{Status: ScheduleFailed, Err: fmt.Errorf("failed to persist schedule: %w", err)})
+	}
+	s.mu.Unlock()
+
+	// Start outside the lock: a remote start polls for up to a few minutes.
+	for _, d := range due {
+		outcomes = append(outcomes, s.runRule(ctx, d.rule, d.at))
+	}
+	return outcomes
+}
+
+// runRule starts the engine for one run and waits for the command to finish.
+func (s *Scheduler) runRule(ctx context.Context, r ScheduleRule, at time.Time) ScheduleOutcome {
+	o := ScheduleOutcome{RuleID: r.ID, Scheduled: at}
+	run := r.RunMinutes
+	if run == 0 {
+		run = DefaultScheduleRunMinutes
+	}
+	states, err := s.start(ctx, run, 0, false, r.Profile)
+	if err != nil {
+		o.Status, o.Err = ScheduleFailed, err
+		return o
+	}
+	for state := range states {
+		o.FinalState = state
+	}
+	if o.FinalState == "error" {
+		o.Status, o.Err = ScheduleFailed, errors.New("remote engine start failed")
+		return o
+	}
+	o.Status = ScheduleStarted
+	return o
+}
+
+// excluded reports whether t falls on an exclusion date. Callers hold s.mu.
+func (s *Scheduler) excluded(t time.Time) bool {
+	return slices.Contains(s.state.Exclusions, t.In(s.loc).Format(scheduleDateLayout))
+}
+
+// ruleIndex returns the index of the rule with the given ID, or -1. Callers hold s.mu.
+func (s *Scheduler) ruleIndex(id string) int {
+	return slices.IndexFunc(s.state.Rules, func(r ScheduleRule) bool { return r.ID == id })
+}
+
+// save persists the state if a store is configured. Callers hold s.mu.
+func (s *Scheduler) save() error {
+	if s.store == nil {
+		return nil
+	}
+	return s.store.Save(s.state)
+}
+
+// validate checks the rule has an ID and a parseable schedule.
+func (r ScheduleRule) validate() error {
+	if r.ID == "" {
+		return errors.New("schedule rule ID cannot be empty")
+	}
+	if !slices.Contains(climateRunTimes, r.RunMinutes) {
+		return fmt.Errorf("run time must be one of %v minutes, got %d", climateRunTimes, r.RunMinutes)
+	}
+	_, err := r.spec()
+	return err
+}
+
+// spec converts the rule to a cron spec. At/Weekdays/Months rules are
+// translated to the equivalent cron expression.
+func (r ScheduleRule) spec() (*cronSpec, error) {
+	switch {
+	case r.Cron != "" && r.At != "":
+		return nil, errors.New("schedule rule must set either Cron or At, not both")
+	case r.Cron != "":
+		return parseCron(r.Cron)
+	case r.At == "":
+		return nil, errors.New("schedule rule must set Cron or At")
+	}
+
+	at, err := time.Parse("15:04", r.At)
+	if err != nil {
+		return nil, fmt.Errorf("schedule time must be in HH:MM format, got %s", r.At)
+	}
+	days := make([]int, 0, len(r.Weekdays))
+	for _, d := range r.Weekdays {
+		days = append(days, int(d))
+	}
+	if err := ValidateDaysOfWeek(days); err != nil {
+		return nil, err
+	}
+	months := make([]int, 0, len(r.Months))
+	for _, m := range r.Months {
+		months = append(months, int(m))
+	}
+	return parseCron(fmt.Sprintf("%d %d * %s %s", at.Minute(), at.Hour(), cronList(months), cronList(days)))
+}
+
+// cronList formats values as a cron list field, "*" when empty.
+func cronList(values []int) string {
+	if len(values) == 0 {
+		return "*"
+	}
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ",")
+}
+
+// cronSpec is a parsed five-field cron expression, one bit per allowed value.
+type cronSpec struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+// parseCron parses "minute hour day-of-month month day-of-week". Fields accept
+// "*", values, ranges ("1-5"), lists ("1,3") and steps ("*/15", "0-30/10").
+// Day of week is 0-7 with both 0 and 7 meaning Sunday.
+func parseCron(expr string) (*cronSpec, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("cron expression must have 5 fields, got %d: %q", len(fields), expr)
+	}
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	var bits [5]uint64
+	for i, f := range fields {
+		b, err := parseCronField(f, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron field %q in %q: %w", f, expr, err)
+		}
+		bits[i] = b
+	}
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return &cronSpec{
+		minute: bits[0], hour: bits[1], dom: bits[2], month: bits[3], dow: bits[4],
+		domAny: strings.HasPrefix(fields[2], "*"),
+		dowAny: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField parses one cron field into a bit set.
+func parseCronField(f string, lo, hi int) (uint64, error) {
+	var bits uint64
+	for part := range strings.SplitSeq(f, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepStr)
+			}
+			step = n
+		}
+		from, to := lo, hi
+		if rng != "*" {
+			a, b, isRange := strings.Cut(rng, "-")
+			n, err := strconv.Atoi(a)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", a)
+			}
+			from, to = n, n
+			if isRange {
+				if to, err = strconv.Atoi(b); err != nil {
+					return 0, fmt.Errorf("invalid value %q", b)
+				}
+			} else if hasStep {
+				to = hi
+			}
+		}
+		if from < lo || to > hi || from > to {
+			return 0, fmt.Errorf("range %d-%d outside %d-%d", from, to, lo, hi)
+		}
+		for i := from; i <= to; i += step {
+			bits |= 1 << i
+		}
+	}
+	return bits, nil
+}
+
+// matchDay applies cron's day rule: when both day-of-month and day-of-week are
+// restricted, either may match.
+func (c *cronSpec) matchDay(t time.Time) bool {
+	dom := c.dom&(1<<t.Day()) != 0
+	dow := c.dow&(1<<int(t.Weekday())) != 0
+	if c.domAny || c.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// next returns the first matching minute strictly after t in loc, or the zero
+// time if none occurs within five years.
+func (c *cronSpec) next(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		y, m, d := t.Date()
+		switch {
+		case c.month&(1<<int(m)) == 0:
+			t = time.Date(y, m+1, 1, 0, 0, 0, 0, loc)
+		case !c.matchDay(t):
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<t.Hour()) == 0:
+			t = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,193 @@
+package mysubaru
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testScheduler returns a scheduler in America/New_York with a fake engine
+// start that records the profiles it was called with.
+func testScheduler(t *testing.T, store ScheduleStore, opts SchedulerOptions, created time.Time) (*Scheduler, *[]string) {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	opts.Location = loc
+
+	s, err := NewScheduler(&Vehicle{}, store, opts)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	s.created = created
+	var started []string
+	s.start = func(ctx context.Context, run, delay int, horn bool, profile string) (chan string, error) {
+		started = append(started, profile)
+		ch := make(chan string, 2)
+		ch <- "started"
+		ch <- "finished"
+		close(ch)
+		return ch, nil
+	}
+	return s, &started
+}
+
+// TestCronSpecNext checks next-run computation for cron and weekday rules.
+func TestCronSpecNext(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	// Friday 2026-01-09 08:00 local
+	from := time.Date(2026, 1, 9, 8, 0, 0, 0, loc)
+
+	tests := []struct {
+		name string
+		rule ScheduleRule
+		want time.Time
+	}{
+		{"weekdays at 7:40 skips weekend", ScheduleRule{ID: "a", At: "07:40", Weekdays: []time.Weekday{1, 2, 3, 4, 5}}, time.Date(2026, 1, 12, 7, 40, 0, 0, loc)},
+		{"winter months only", ScheduleRule{ID: "b", At: "07:40", Months: []time.Month{time.November, time.December}}, time.Date(2026, 11, 1, 7, 40, 0, 0, loc)},
+		{"cron every 15 minutes", ScheduleRule{ID: "c", Cron: "*/15 * * * *"}, time.Date(2026, 1, 9, 8, 15, 0, 0, loc)},
+		{"cron sunday as 7", ScheduleRule{ID: "d", Cron: "0 9 * * 7"}, time.Date(2026, 1, 11, 9, 0, 0, 0, loc)},
+		{"cron day of month or weekday", ScheduleRule{ID: "e", Cron: "0 6 10 * 1"}, time.Date(2026, 1, 10, 6, 0, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := tt.rule.spec()
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got := spec.next(from, loc); !got.Equal(tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	for _, bad := range []ScheduleRule{
+		{ID: "x", Cron: "61 * * * *"},
+		{ID: "x", Cron: "* * *"},
+		{ID: "x", At: "7:40pm"},
+		{ID: "x", At: "07:40", Cron: "* * * * *"},
+		{ID: "", At: "07:40"},
+		{ID: "x", At: "07:40", RunMinutes: 7},
+	} {
+		if err := bad.validate(); err == nil {
+			t.Errorf("expected error for %+v", bad)
+		}
+	}
+}
+
+// TestSchedulerCheck covers due runs, exclusions and the missed-run policies.
+func TestSchedulerCheck(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	created := time.Date(2026, 1, 12, 6, 0, 0, 0, loc) // Monday
+	rule := ScheduleRule{ID: "commute", At: "07:40", Weekdays: []time.Weekday{1, 2, 3, 4, 5}, Profile: "winter"}
+
+	t.Run("due run starts the engine", func(t *testing.T) {
+		s, started := testScheduler(t, nil, SchedulerOptions{}, created)
+		if err := s.AddRule(rule); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if out := s.check(context.Background(), time.Date(2026, 1, 12, 7, 30, 0, 0, loc)); len(out) != 0 {
+			t.Fatalf("expected nothing due before 7:40, got %+v", out)
+		}
+		out := s.check(context.Background(), time.Date(2026, 1, 12, 7, 40, 10, 0, loc))
+		if len(out) != 1 || out[0].Status != ScheduleStarted || out[0].FinalState != "finished" {
+			t.Fatalf("expected one started outcome, got %+v", out)
+		}
+		if len(*started) != 1 || (*started)[0] != "winter" {
+			t.Errorf("expected engine start with profile winter, got %v", *started)
+		}
+		if out := s.check(context.Background(), time.Date(2026, 1, 12, 7, 41, 0, 0, loc)); len(out) != 0 {
+			t.Errorf("expected run not to repeat, got %+v", out)
+		}
+	})
+
+	t.Run("excluded date", func(t *testing.T) {
+		s, started := testScheduler(t, nil, SchedulerOptions{}, created)
+		_ = s.AddRule(rule)
+		if err := s.Exclude("2026-01-12"); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		out := s.check(context.Background(), time.Date(2026, 1, 12, 7, 40, 10, 0, loc))
+		if len(out) != 1 || out[0].Status != ScheduleExcluded || len(*started) != 0 {
+			t.Fatalf("expected excluded outcome without start, got %+v / %v", out, *started)
+		}
+		s.now = func() time.Time { return time.Date(2026, 1, 11, 12, 0, 0, 0, loc) }
+		next, _ := s.NextRun("commute")
+		if want := time.Date(2026, 1, 13, 7, 40, 0, 0, loc); !next.Equal(want) {
+			t.Errorf("expected next run %v, got %v", want, next)
+		}
+	})
+
+	t.Run("missed run skipped", func(t *testing.T) {
+		s, started := testScheduler(t, nil, SchedulerOptions{MissedRunPolicy: MissedRunSkip}, created)
+		_ = s.AddRule(rule)
+		out := s.check(context.Background(), time.Date(2026, 1, 14, 9, 0, 0, 0, loc))
+		if len(out) != 1 || out[0].Status != ScheduleMissed || len(*started) != 0 {
+			t.Fatalf("expected a single missed outcome, got %+v / %v", out, *started)
+		}
+		if want := time.Date(2026, 1, 14, 7, 40, 0, 0, loc); !out[0].Scheduled.Equal(want) {
+			t.Errorf("expected missed runs to collapse into %v, got %v", want, out[0].Scheduled)
+		}
+	})
+
+	t.Run("missed run caught up within window", func(t *testing.T) {
+		s, started := testScheduler(t, nil, SchedulerOptions{MissedRunPolicy: MissedRunCatchUp, CatchUpWindow: time.Hour}, created)
+		_ = s.AddRule(rule)
+		out := s.check(context.Background(), time.Date(2026, 1, 12, 8, 10, 0, 0, loc))
+		if len(out) != 1 || out[0].Status != ScheduleStarted || len(*started) != 1 {
+			t.Fatalf("expected caught-up start, got %+v / %v", out, *started)
+		}
+		out = s.check(context.Background(), time.Date(2026, 1, 13, 9, 0, 0, 0, loc))
+		if len(out) != 1 || out[0].Status != ScheduleMissed {
+			t.Fatalf("expected run outside window to be missed, got %+v", out)
+		}
+	})
+
+	t.Run("failed start", func(t *testing.T) {
+		s, _ := testScheduler(t, nil, SchedulerOptions{}, created)
+		s.start = func(ctx context.Context, run, delay int, horn bool, profile string) (chan string, error) {
+			return nil, errors.New("boom")
+		}
+		_ = s.AddRule(rule)
+		out := s.check(context.Background(), time.Date(2026, 1, 12, 7, 40, 0, 0, loc))
+		if len(out) != 1 || out[0].Status != ScheduleFailed || out[0].Err == nil {
+			t.Fatalf("expected failed outcome, got %+v", out)
+		}
+	})
+}
+
+// TestFileScheduleStore verifies rules, exclusions and last runs survive a restart.
+func TestFileScheduleStore(t *testing.T) {
+	store := FileScheduleStore{Path: filepath.Join(t.TempDir(), "schedule.json")}
+	created := time.Date(2026, 1, 12, 6, 0, 0, 0, time.UTC)
+
+	s, _ := testScheduler(t, store, SchedulerOptions{}, created)
+	if err := s.AddRule(ScheduleRule{ID: "commute", At: "07:40", Profile: "winter"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := s.Exclude("2026-12-25"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	s.check(context.Background(), time.Date(2026, 1, 12, 7, 40, 0, 0, s.loc))
+
+	reloaded, _ := testScheduler(t, store, SchedulerOptions{}, created)
+	rules := reloaded.Rules()
+	if len(rules) != 1 || rules[0].Profile != "winter" || rules[0].LastRun.IsZero() {
+		t.Fatalf("expected persisted rule with last run, got %+v", rules)
+	}
+	if len(reloaded.state.Exclusions) != 1 || reloaded.state.Exclusions[0] != "2026-12-25" {
+		t.Errorf("expected persisted exclusion, got %v", reloaded.state.Exclusions)
+	}
+	if err := reloaded.RemoveRule("commute"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
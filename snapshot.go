@@ -0,0 +1,190 @@
+package mysubaru
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// VehicleSnapshot is a point-in-time copy of a vehicle's state, taken with
+// Vehicle.Snapshot. It shares no memory with the vehicle, so it can be read
+// from any goroutine while pollers keep updating the vehicle.
+type VehicleSnapshot struct {
+	Vin                  string
+	CarName              string
+	CarNickname          string
+	ModelName            string
+	ModelYear            string
+	ModelCode            string
+	LicensePlate         string
+	Features             []string
+	SubscriptionFeatures []string
+	SubscriptionStatus   string
+	TimeZone             string
+	StolenVehicle        bool
+	EngineState          IgnitionState
+	Odometer             Odometer
+	DistanceToEmpty      DistanceToEmpty
+	FuelConsumptionAvg   FuelConsumption
+	OutsideTemp          OutsideTemperature
+	ClimateProfiles      map[string]ClimateProfile
+	Doors                map[string]Door
+	Windows              map[string]Window
+	Tires                map[string]Tire
+	Troubles             map[string]Trouble
+	GeoLocation          GeoLocation
+	EVStatus             EVStatus
+	Updated              time.Time // when the vehicle was last updated
+	Taken                time.Time // when the snapshot was taken
+}
+
+// Snapshot returns a deep copy of the vehicle's current state.
+func (v *Vehicle) Snapshot() VehicleSnapshot {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.snapshot()
+}
+
+// snapshot copies the vehicle's state. Callers hold v.mu.
+func (v *Vehicle) snapshot() VehicleSnapshot {
+	s := VehicleSnapshot{
+		Vin:                  v.Vin,
+		CarName:              v.CarName,
+		CarNickname:          v.CarNickname,
+		ModelName:            v.ModelName,
+		ModelYear:            v.ModelYear,
+		ModelCode:            v.ModelCode,
+		LicensePlate:         v.LicensePlate,
+		Features:             slices.Clone(v.Features),
+		SubscriptionFeatures: slices.Clone(v.SubscriptionFeatures),
+		SubscriptionStatus:   v.SubscriptionStatus,
+		TimeZone:             v.TimeZone,
+		StolenVehicle:        v.StolenVehicle,
+		EngineState:          v.EngineState,
+		Odometer:             v.Odometer,
+		DistanceToEmpty:      v.DistanceToEmpty,
+		FuelConsumptionAvg:   v.FuelConsumptionAvg,
+		OutsideTemp:          v.OutsideTemp,
+		ClimateProfiles:      maps.Clone(v.ClimateProfiles),
+		Doors:                maps.Clone(v.Doors),
+		Windows:              maps.Clone(v.Windows),
+		Tires:                maps.Clone(v.Tires),
+		Troubles:             maps.Clone(v.Troubles),
+		GeoLocation:          v.GeoLocation,
+		EVStatus:             v.EVStatus,
+		Updated:              v.Updated,
+		Taken:                time.Now(),
+	}
+	s.EVStatus.ChargeSettings.Schedules = slices.Clone(v.EVStatus.ChargeSettings.Schedules)
+	for i, cs := range s.EVStatus.ChargeSettings.Schedules {
+		s.EVStatus.ChargeSettings.Schedules[i].DaysOfWeek = slices.Clone(cs.DaysOfWeek)
+	}
+	return s
+}
+
+// Change is a field that differs between two snapshots.
+type Change struct {
+	Field string // path of the field, e.g. "Doors[door_front_left].Status"
+	Old   any    // nil when a map entry was added
+	New   any    // nil when a map entry was removed
+}
+
+func (c Change) String() string {
+	switch {
+	case c.Old == nil:
+		return fmt.Sprintf("%s: added %+v", c.Field, c.New)
+	case c.New == nil:
+		return fmt.Sprintf("%s: removed %+v", c.Field, c.Old)
+	}
+	if d, ok := changeDelta(c.Old, c.New); ok {
+		return c.Field + ": " + d
+	}
+	return fmt.Sprintf("%s: %v → %v", c.Field, c.Old, c.New)
+}
+
+// changeDelta formats the difference between two numeric values or two
+// distances in the same unit, e.g. "+12 mi".
+func changeDelta(a, b any) (string, bool) {
+	signed := func(d float64) string {
+		s := strconv.FormatFloat(math.Round(d*10)/10, 'f', -1, 64)
+		if d > 0 {
+			s = "+" + s
+		}
+		return s
+	}
+	switch a := a.(type) {
+	case Distance:
+		if b, ok := b.(Distance); ok && a.Unit == b.Unit {
+			return signed(b.Value-a.Value) + " " + string(a.Unit), true
+		}
+	case int:
+		if b, ok := b.(int); ok {
+			return fmt.Sprintf("%d → %d (%s)", a, b, signed(float64(b-a))), true
+		}
+	case float64:
+		if b, ok := b.(float64); ok {
+			return fmt.Sprintf("%v → %v (%s)", a, b, signed(b-a)), true
+		}
+	}
+	return "", false
+}
+
+// Diff lists the fields that changed from a to b, ordered by field and map
+// key. Map entries that appear or disappear, such as a new trouble code, are
+// reported whole. Timestamps (the Updated fields and Taken) are not compared,
+// so a poll that reports the same state yields no changes.
+func Diff(a, b VehicleSnapshot) []Change {
+	var changes []Change
+	diffValue("", reflect.ValueOf(a), reflect.ValueOf(b), &changes)
+	return changes
+}
+
+// diffValue appends the changes between a and b, found at path, to changes.
+// Structs are compared field by field unless they print themselves (the
+// quantity types), maps entry by entry and everything else as a whole.
+func diffValue(path string, a, b reflect.Value, changes *[]Change) {
+	switch a.Kind() {
+	case reflect.Struct:
+		if _, ok := a.Interface().(fmt.Stringer); ok {
+			break
+		}
+		for i := range a.NumField() {
+			f := a.Type().Field(i)
+			if !f.IsExported() || f.Name == "Updated" || f.Name == "Taken" {
+				continue
+			}
+			name := f.Name
+			if path != "" {
+				name = path + "." + f.Name
+			}
+			diffValue(name, a.Field(i), b.Field(i), changes)
+		}
+		return
+	case reflect.Map:
+		keys := map[string]reflect.Value{}
+		for _, k := range append(a.MapKeys(), b.MapKeys()...) {
+			keys[fmt.Sprint(k.Interface())] = k
+		}
+		for _, name := range slices.Sorted(maps.Keys(keys)) {
+			k := keys[name]
+			av, bv := a.MapIndex(k), b.MapIndex(k)
+			field := path + "[" + name + "]"
+			switch {
+			case !av.IsValid():
+				*changes = append(*changes, Change{Field: field, New: bv.Interface()})
+			case !bv.IsValid():
+				*changes = append(*changes, Change{Field: field, Old: av.Interface()})
+			default:
+				diffValue(field, av, bv, changes)
+			}
+		}
+		return
+	}
+	if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+		*changes = append(*changes, Change{Field: path, Old: a.Interface(), New: b.Interface()})
+	}
+}
@@ -0,0 +1,71 @@
+package mysubaru
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestSnapshot verifies a snapshot is a deep copy unaffected by later updates.
+func TestSnapshot(t *testing.T) {
+	v := &Vehicle{
+		Vin:      "1HGCM82633A004352",
+		Features: []string{"g3", "PHEV"},
+		Doors:    map[string]Door{"door_front_left": {Position: "front", SubPosition: "left", Status: DoorClosed}},
+		Troubles: map[string]Trouble{},
+	}
+	v.EVStatus.ChargeSettings.Schedules = []ChargeSchedule{{Enabled: true, StartTime: "22:00", EndTime: "06:00", DaysOfWeek: []int{1, 2}}}
+	s := v.Snapshot()
+
+	v.mu.Lock()
+	v.Features[0] = "g2"
+	v.Doors["door_front_left"] = Door{Status: DoorOpen}
+	v.Troubles["ABS_MIL"] = Trouble{Description: "ABS"}
+	v.EVStatus.ChargeSettings.Schedules[0].DaysOfWeek[0] = 6
+	v.mu.Unlock()
+
+	if s.Features[0] != "g3" || s.Doors["door_front_left"].Status != DoorClosed || len(s.Troubles) != 0 {
+		t.Errorf("expected the snapshot to keep its state, got %+v", s)
+	}
+	if !slices.Equal(s.EVStatus.ChargeSettings.Schedules[0].DaysOfWeek, []int{1, 2}) {
+		t.Errorf("expected the charge schedule to be copied, got %v", s.EVStatus.ChargeSettings.Schedules[0].DaysOfWeek)
+	}
+	if s.Taken.IsZero() {
+		t.Error("expected the snapshot time to be set")
+	}
+}
+
+// TestDiff verifies Diff reports changed fields and map entries, ignoring
+// timestamps.
+func TestDiff(t *testing.T) {
+	v := &Vehicle{
+		Doors:    map[string]Door{"door_front_left": {Status: DoorClosed, Lock: DoorLocked}},
+		Troubles: map[string]Trouble{"OPL_MIL": {Description: "Oil Pressure"}},
+	}
+	v.Odometer.Distance = DistanceMiles(24999)
+	a := v.Snapshot()
+	if changes := Diff(a, v.Snapshot()); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+
+	v.mu.Lock()
+	v.Doors["door_front_left"] = Door{Status: DoorOpen, Lock: DoorLocked}
+	v.Odometer.Miles = 25011
+	v.Odometer.Distance = DistanceMiles(25011)
+	v.Troubles = map[string]Trouble{"ABS_MIL": {Description: "ABS"}}
+	v.mu.Unlock()
+
+	var got []string
+	for _, c := range Diff(a, v.Snapshot()) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"Odometer.Miles: 0 → 25011 (+25011)",
+		"Odometer.Distance: +12 mi",
+		"Doors[door_front_left].Status: CLOSED → OPEN",
+		"Troubles[ABS_MIL]: added {Description:ABS}",
+		"Troubles[OPL_MIL]: removed {Description:Oil Pressure}",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
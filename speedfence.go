@@ -0,0 +1,236 @@
+package mysubaru
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SpeedUnit is the unit of a Speed.
+type SpeedUnit string
+
+const (
+	MPH SpeedUnit = "MPH"
+	KPH SpeedUnit = "KPH"
+)
+
+// kmPerMile converts between MPH and KPH.
+const kmPerMile = 1.609344
+
+// Speed is a speed value with its unit.
+type Speed struct {
+	Value float64
+	Unit  SpeedUnit
+}
+
+// SpeedMPH returns a Speed of mph miles per hour.
+func SpeedMPH(mph float64) Speed { return Speed{Value: mph, Unit: MPH} }
+
+// SpeedKPH returns a Speed of kph kilometers per hour.
+func SpeedKPH(kph float64) Speed { return Speed{Value: kph, Unit: KPH} }
+
+// MPH returns the speed in miles per hour.
+func (s Speed) MPH() float64 {
+	if s.Unit == KPH {
+		return s.Value / kmPerMile
+	}
+	return s.Value
+}
+
+// KPH returns the speed in kilometers per hour.
+func (s Speed) KPH() float64 {
+	if s.Unit == KPH {
+		return s.Value
+	}
+	return s.Value * kmPerMile
+}
+
+// In returns the speed converted to unit.
+func (s Speed) In(unit SpeedUnit) Speed {
+	if unit == KPH {
+		return SpeedKPH(s.KPH())
+	}
+	return SpeedMPH(s.MPH())
+}
+
+func (s Speed) String() string {
+	return strconv.FormatFloat(s.Value, 'f', -1, 64) + " " + string(s.unit())
+}
+
+// Validate checks the unit and that the speed is within the limits accepted by
+// ValidateSpeedLimit.
+func (s Speed) Validate() error {
+	switch s.Unit {
+	case "", MPH, KPH:
+	default:
+		return fmt.Errorf("unknown speed unit %q", s.Unit)
+	}
+	return ValidateSpeedLimit(int(math.Round(s.MPH())))
+}
+
+// unit returns the speed's unit, MPH when unset.
+func (s Speed) unit() SpeedUnit {
+	if s.Unit == "" {
+		return MPH
+	}
+	return s.Unit
+}
+
+// parseSpeedUnit maps the unit spellings the API uses to a SpeedUnit.
+func parseSpeedUnit(u string) SpeedUnit {
+	switch strings.ToUpper(strings.TrimSpace(u)) {
+	case "KPH", "KMH", "KM/H", "KPHR", "KILOMETERS":
+		return KPH
+	}
+	return MPH
+}
+
+// =============================================================================
+// Speed Fence (Speed Alerts)
+// =============================================================================
+
+// SpeedFenceSettings represents a speed alert configuration. It reads and
+// writes the speedLimit/speedUnit fields of the fetch and save endpoints.
+type SpeedFenceSettings struct {
+	Enabled bool
+	Limit   Speed
+}
+
+// UnmarshalJSON reads speedLimit with its unit from speedUnit or
+// speedLimitUnit, defaulting to MPH.
+func (s *SpeedFenceSettings) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		Enabled        bool        `json:"enabled"`
+		SpeedLimit     json.Number `json:"speedLimit"`
+		SpeedUnit      string      `json:"speedUnit"`
+		SpeedLimitUnit string      `json:"speedLimitUnit"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	var limit float64
+	if aux.SpeedLimit != "" {
+		var err error
+		if limit, err = aux.SpeedLimit.Float64(); err != nil {
+			return fmt.Errorf("invalid speedLimit %q: %w", aux.SpeedLimit, err)
+		}
+	}
+	unit := aux.SpeedUnit
+	if unit == "" {
+		unit = aux.SpeedLimitUnit
+	}
+	*s = SpeedFenceSettings{Enabled: aux.Enabled, Limit: Speed{Value: limit, Unit: parseSpeedUnit(unit)}}
+	return nil
+}
+
+// MarshalJSON writes the settings in the shape the fetch endpoint returns.
+func (s SpeedFenceSettings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Enabled    bool      `json:"enabled"`
+		SpeedLimit float64   `json:"speedLimit"`
+		SpeedUnit  SpeedUnit `json:"speedUnit"`
+	}{s.Enabled, s.Limit.Value, s.Limit.unit()})
+}
+
+// Validate checks the speed limit.
+func (s SpeedFenceSettings) Validate() error {
+	if err := s.Limit.Validate(); err != nil {
+		return fmt.Errorf("invalid speed limit: %w", err)
+	}
+	return nil
+}
+
+// params returns the request parameters shared by the save and execute
+// endpoints. The limit is sent as a whole number in its own unit.
+func (s SpeedFenceSettings) params(vin string) map[string]string {
+	return map[string]string{
+		"vin":        vin,
+		"speedLimit": strconv.Itoa(int(math.Round(s.Limit.Value))),
+		"speedUnit":  string(s.Limit.unit()),
+		"enabled":    strconv.FormatBool(s.Enabled),
+	}
+}
+
+// GetSpeedFenceSettings retrieves the current speed fence (speed alert) settings
+func (v *Vehicle) GetSpeedFenceSettings(ctx context.Context) (*SpeedFenceSettings, error) {
+	if err := v.checkSafetyFeature("speed fence"); err != nil {
+		return nil, err
+	}
+	var settings SpeedFenceSettings
+	if err := v.fetchInto(ctx, GET, "API_G2_SPEEDFENCE_FETCH", map[string]string{}, false, &settings); err != nil {
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// SaveSpeedFenceSettings validates and saves speed fence (speed alert) settings
+func (v *Vehicle) SaveSpeedFenceSettings(ctx context.Context, settings SpeedFenceSettings) error {
+	if err := v.checkSafetyFeature("speed fence"); err != nil {
+		return err
+	}
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	return v.fetchInto(ctx, POST, "API_G2_SPEEDFENCE_SAVE", settings.params(v.Vin), true, nil)
+}
+
+// SetSpeedFence sets up a speed fence for the vehicle and sends it to the
+// vehicle right away.
+// Parameters:
+//   - settings: Speed limit and whether the speed fence is active
+//   - persistent: Whether to keep the setting across restarts
+func (v *Vehicle) SetSpeedFence(ctx context.Context, settings SpeedFenceSettings, persistent bool) (chan string, error) {
+	if err := v.checkSafetyFeature("speed fence"); err != nil {
+		return nil, err
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := settings.params(v.Vin)
+	params["delay"] = "0"
+	params["pin"] = pin
+	params["persistent"] = strconv.FormatBool(persistent)
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilitySpeedFence, "API_G2_SPEEDFENCE")
+	if err != nil {
+		return nil, err
+	}
+
+	return v.actuate(ctx, params, reqUrl, pollingUrl)
+}
+
+// ActivateSpeedFence activates the speed fence alert on the vehicle
+func (v *Vehicle) ActivateSpeedFence(ctx context.Context) (chan string, error) {
+	return v.switchSpeedFence(ctx, "activate")
+}
+
+// DeactivateSpeedFence deactivates the speed fence alert on the vehicle
+func (v *Vehicle) DeactivateSpeedFence(ctx context.Context) (chan string, error) {
+	return v.switchSpeedFence(ctx, "deactivate")
+}
+
+func (v *Vehicle) switchSpeedFence(ctx context.Context, action string) (chan string, error) {
+	if err := v.checkSafetyFeature("speed fence"); err != nil {
+		return nil, err
+	}
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		"vin":    v.Vin,
+		"pin":    pin,
+		"action": action,
+	}
+	reqUrl := v.endpointURL("API_G2_SPEEDFENCE")
+	pollingUrl := v.endpointURL("API_G2_SPEEDFENCE_STATUS")
+
+	return v.actuate(ctx, params, reqUrl, pollingUrl)
+}
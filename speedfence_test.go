@@ -0,0 +1,52 @@
+package mysubaru
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+// TestSpeed covers unit conversion and validation in either unit.
+func TestSpeed(t *testing.T) {
+	if kph := SpeedMPH(65).KPH(); math.Abs(kph-104.6) > 0.1 {
+		t.Errorf("expected about 104.6 km/h, got %.2f", kph)
+	}
+	if s := SpeedKPH(100).In(MPH); s.Unit != MPH || math.Abs(s.Value-62.14) > 0.01 {
+		t.Errorf("expected about 62.14 MPH, got %v", s)
+	}
+	if err := SpeedKPH(200).Validate(); err != nil {
+		t.Errorf("expected 200 km/h to be valid, got %v", err)
+	}
+	if err := SpeedKPH(240).Validate(); err == nil {
+		t.Error("expected 240 km/h (149 mph) to be rejected")
+	}
+	if err := (Speed{Value: 50, Unit: "knots"}).Validate(); err == nil {
+		t.Error("expected unknown unit to be rejected")
+	}
+}
+
+// TestSpeedFenceSettings_RoundTrip reads the fetch shape and checks it survives
+// a marshal/unmarshal cycle and produces the save parameters.
+func TestSpeedFenceSettings_RoundTrip(t *testing.T) {
+	var s SpeedFenceSettings
+	if err := json.Unmarshal([]byte(`{"enabled":true,"speedLimit":110,"speedLimitUnit":"KPH"}`), &s); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !s.Enabled || s.Limit != SpeedKPH(110) {
+		t.Fatalf("unexpected settings: %+v", s)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var back SpeedFenceSettings
+	if err := json.Unmarshal(data, &back); err != nil || back != s {
+		t.Errorf("expected %+v after round trip, got %+v (%v)", s, back, err)
+	}
+
+	params := s.params("VIN")
+	if params["speedLimit"] != "110" || params["speedUnit"] != "KPH" || params["enabled"] != "true" {
+		t.Errorf("unexpected params: %v", params)
+	}
+}
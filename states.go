@@ -0,0 +1,146 @@
+package mysubaru
+
+import (
+	"slices"
+	"strings"
+)
+
+// =============================================================================
+// Vehicle States
+// =============================================================================
+
+// The state types below hold the wire values the API reports. Values a type
+// doesn't know, and the NOT_EQUIPPED/UNKNOWN placeholders, parse as its
+// Unknown value, which is the zero value and marshals as an empty string.
+
+// DoorStatus is the position of a door, the boot or the engine hood.
+type DoorStatus string
+
+const (
+	DoorStatusUnknown DoorStatus = ""
+	DoorOpen          DoorStatus = DOOR_OPEN
+	DoorClosed        DoorStatus = DOOR_CLOSED
+)
+
+// ParseDoorStatus parses a reported door position.
+func ParseDoorStatus(s string) DoorStatus {
+	return parseState(s, DoorOpen, DoorClosed)
+}
+
+func (s DoorStatus) String() string { return stateString(s) }
+
+// UnmarshalText parses text with ParseDoorStatus.
+func (s *DoorStatus) UnmarshalText(text []byte) error {
+	*s = ParseDoorStatus(string(text))
+	return nil
+}
+
+// WindowStatus is the position of a window or the sunroof.
+type WindowStatus string
+
+const (
+	WindowStatusUnknown WindowStatus = ""
+	WindowClosed        WindowStatus = WINDOW_CLOSED
+	WindowVented        WindowStatus = "VENTED"
+	WindowPartlyOpen    WindowStatus = "SLIDE_PARTLY_OPEN"
+	WindowOpen          WindowStatus = WINDOW_OPEN
+)
+
+// ParseWindowStatus parses a reported window position.
+func ParseWindowStatus(s string) WindowStatus {
+	return parseState(s, WindowClosed, WindowVented, WindowPartlyOpen, WindowOpen)
+}
+
+func (s WindowStatus) String() string { return stateString(s) }
+
+// UnmarshalText parses text with ParseWindowStatus.
+func (s *WindowStatus) UnmarshalText(text []byte) error {
+	*s = ParseWindowStatus(string(text))
+	return nil
+}
+
+// LockStatus is the lock state of a door.
+type LockStatus string
+
+const (
+	LockStatusUnknown LockStatus = ""
+	DoorLocked        LockStatus = "LOCKED"
+	DoorUnlocked      LockStatus = "UNLOCKED"
+)
+
+// ParseLockStatus parses a reported door lock state.
+func ParseLockStatus(s string) LockStatus {
+	return parseState(s, DoorLocked, DoorUnlocked)
+}
+
+func (s LockStatus) String() string { return stateString(s) }
+
+// UnmarshalText parses text with ParseLockStatus.
+func (s *LockStatus) UnmarshalText(text []byte) error {
+	*s = ParseLockStatus(string(text))
+	return nil
+}
+
+// IgnitionState is the vehicle's ignition state.
+type IgnitionState string
+
+const (
+	IgnitionUnknown IgnitionState = ""
+	IgnitionOff     IgnitionState = "IGNITION_OFF"
+	IgnitionOn      IgnitionState = IGNITION_ON
+)
+
+// ParseIgnitionState parses a reported vehicleStateType.
+func ParseIgnitionState(s string) IgnitionState {
+	return parseState(s, IgnitionOff, IgnitionOn)
+}
+
+func (s IgnitionState) String() string { return stateString(s) }
+
+// UnmarshalText parses text with ParseIgnitionState.
+func (s *IgnitionState) UnmarshalText(text []byte) error {
+	*s = ParseIgnitionState(string(text))
+	return nil
+}
+
+// ChargerState is the state of an EV's charger.
+type ChargerState string
+
+const (
+	ChargerStateUnknown ChargerState = ""
+	ChargerCharging     ChargerState = CHARGING
+	ChargerStopped      ChargerState = "CHARGING_STOPPED"
+	ChargerNotCharging  ChargerState = "NOT_CHARGING"
+)
+
+// ParseChargerState parses a reported evChargerStateType.
+func ParseChargerState(s string) ChargerState {
+	return parseState(s, ChargerCharging, ChargerStopped, ChargerNotCharging)
+}
+
+func (s ChargerState) String() string { return stateString(s) }
+
+// UnmarshalText parses text with ParseChargerState.
+func (s *ChargerState) UnmarshalText(text []byte) error {
+	*s = ParseChargerState(string(text))
+	return nil
+}
+
+// parseState returns the known value matching s, ignoring case and
+// surrounding space, or the zero (Unknown) value.
+func parseState[T ~string](s string, known ...T) T {
+	v := T(strings.ToUpper(strings.TrimSpace(s)))
+	if slices.Contains(known, v) {
+		return v
+	}
+	var unknown T
+	return unknown
+}
+
+// stateString returns s, or "UNKNOWN" for the Unknown value.
+func stateString[T ~string](s T) string {
+	if s == "" {
+		return "UNKNOWN"
+	}
+	return string(s)
+}
@@ -0,0 +1,56 @@
+package mysubaru
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestParseStates checks wire values parse to their states and anything else
+// to Unknown.
+func TestParseStates(t *testing.T) {
+	tests := []struct {
+		got, want any
+	}{
+		{ParseDoorStatus("OPEN"), DoorOpen},
+		{ParseDoorStatus(" closed "), DoorClosed},
+		{ParseDoorStatus("NOT_EQUIPPED"), DoorStatusUnknown},
+		{ParseWindowStatus("CLOSE"), WindowClosed},
+		{ParseWindowStatus("SLIDE_PARTLY_OPEN"), WindowPartlyOpen},
+		{ParseWindowStatus("UNKNOWN"), WindowStatusUnknown},
+		{ParseLockStatus("unlocked"), DoorUnlocked},
+		{ParseLockStatus(""), LockStatusUnknown},
+		{ParseIgnitionState("IGNITION_ON"), IgnitionOn},
+		{ParseIgnitionState("ACC"), IgnitionUnknown},
+		{ParseChargerState("CHARGING_STOPPED"), ChargerStopped},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("expected %v, got %v", tt.want, tt.got)
+		}
+	}
+	if s := IgnitionUnknown.String(); s != "UNKNOWN" {
+		t.Errorf("expected UNKNOWN, got %q", s)
+	}
+}
+
+// TestStatesJSON verifies states marshal as their wire values and unmarshal
+// through parsing.
+func TestStatesJSON(t *testing.T) {
+	d := Door{Status: DoorOpen, Lock: DoorLocked}
+	b, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var got Door
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got.Status != DoorOpen || got.Lock != DoorLocked {
+		t.Errorf("expected an open locked door, got %+v", got)
+	}
+
+	var w Window
+	if err := json.Unmarshal([]byte(`{"Status":"tilted"}`), &w); err != nil || w.Status != WindowStatusUnknown {
+		t.Errorf("expected an unknown window status, got %q, %v", w.Status, err)
+	}
+}
@@ -0,0 +1,232 @@
+package mysubaru
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultTireLowTolerance  = 0.10            // 10% below placard
+	DefaultTireHighTolerance = 0.10            // 10% above placard
+	DefaultTireLeakRate      = 2.0             // PSI lost per hour
+	DefaultTireLeakWindow    = 2 * time.Hour   // history considered for leaks
+	tireHistoryRetention     = 24 * time.Hour  // pressure samples kept per tire
+	tireHistoryMerge         = time.Minute     // samples closer than this are merged
+	tireLeakMinDrop          = 1.0             // PSI; smaller drops are sensor noise
+	tireLeakMinSpan          = 5 * time.Minute // shortest history a leak rate is taken over
+)
+
+// TireCondition is the health of a tire compared with its placard pressure.
+type TireCondition string
+
+const (
+	TireConditionUnknown TireCondition = "" // no valid reading or no placard
+	TireOK               TireCondition = "OK"
+	TireLow              TireCondition = "LOW"
+	TireHigh             TireCondition = "HIGH"
+	TireFastLeak         TireCondition = "FAST_LEAK"
+)
+
+func (c TireCondition) String() string { return stateString(c) }
+
+// TirePlacard holds the recommended cold pressures printed on the door placard.
+type TirePlacard struct {
+	Front Pressure
+	Rear  Pressure
+}
+
+// For returns the placard pressure for a tire position ("Front" or "Rear").
+func (p TirePlacard) For(position string) Pressure {
+	if strings.EqualFold(position, "Rear") {
+		return p.Rear
+	}
+	return p.Front
+}
+
+// TirePlacardFromFeatures derives the placard pressures from the TIF_ (front)
+// and TIR_ (rear) feature codes, e.g. TIF_35 and TIR_33. Values above 100 are
+// taken as kPa, smaller ones as PSI. ok is false unless both are present.
+func TirePlacardFromFeatures(features []string) (p TirePlacard, ok bool) {
+	for _, f := range features {
+		value, front := strings.CutPrefix(f, FEATURE_TIRE_FRONT_PLACARD)
+		if !front {
+			var rear bool
+			if value, rear = strings.CutPrefix(f, FEATURE_TIRE_REAR_PLACARD); !rear {
+				continue
+			}
+		}
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil || n <= 0 {
+			continue
+		}
+		pressure := PressurePSI(n)
+		if n > 100 {
+			pressure = PressureKPa(n)
+		}
+		if front {
+			p.Front = pressure
+		} else {
+			p.Rear = pressure
+		}
+	}
+	return p, p.Front.Value > 0 && p.Rear.Value > 0
+}
+
+// TirePlacard returns the vehicle's placard pressures in the client's unit
+// system. ok is false when the vehicle's features don't report them.
+func (v *Vehicle) TirePlacard() (TirePlacard, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	p, ok := TirePlacardFromFeatures(v.Features)
+	sys := v.unitSystem()
+	return TirePlacard{Front: p.Front.For(sys), Rear: p.Rear.For(sys)}, ok
+}
+
+// TirePressureSample is a tire pressure reading at a point in time.
+type TirePressureSample struct {
+	Time     time.Time
+	Pressure Pressure
+}
+
+// TirePressureHistory returns the readings recorded for the tire named by the
+// Tires key (e.g. "tire_front_left") over the last day, oldest first.
+func (v *Vehicle) TirePressureHistory(tire string) []TirePressureSample {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	h := v.tireHistory[tire]
+	out := make([]TirePressureSample, len(h))
+	for i, s := range h {
+		out[i] = TirePressureSample{Time: s.Time, Pressure: s.Pressure.For(v.unitSystem())}
+	}
+	return out
+}
+
+// recordTirePressure adds a reading, stamped with the time it was taken, to the
+// tire's history, dropping samples older than a day. A reading within a minute
+// of the previous one replaces it, since status and condition responses report
+// the same reading; one older than the previous is ignored. Callers hold v.mu.
+func (v *Vehicle) recordTirePressure(tire string, p Pressure, at time.Time) {
+	if v.tireHistory == nil {
+		v.tireHistory = make(map[string][]TirePressureSample)
+	}
+	h := v.tireHistory[tire]
+	s := TirePressureSample{Time: at, Pressure: p}
+	if n := len(h); n > 0 && at.Before(h[n-1].Time) {
+		return
+	} else if n > 0 && at.Sub(h[n-1].Time) < tireHistoryMerge {
+		h[n-1] = s
+	} else {
+		h = append(h, s)
+	}
+	i := 0
+	for i < len(h)-1 && at.Sub(h[i].Time) > tireHistoryRetention {
+		i++
+	}
+	v.tireHistory[tire] = h[i:]
+}
+
+// TireHealthOptions configures TireHealth. Zero values use the defaults.
+type TireHealthOptions struct {
+	LowTolerance  float64       // fraction below placard reported as low, default 0.10
+	HighTolerance float64       // fraction above placard reported as high, default 0.10
+	LeakRate      float64       // PSI lost per hour reported as a fast leak, default 2
+	LeakWindow    time.Duration // history the leak rate is measured over, default 2h
+}
+
+// TireHealth is a tire's current reading compared with its placard pressure.
+type TireHealth struct {
+	Position    string
+	SubPosition string
+	Reading     Pressure // in the client's unit system, zero when unknown
+	Placard     Pressure // in the client's unit system, zero when unknown
+	Deviation   float64  // fraction above (positive) or below placard
+	LeakRate    float64  // PSI lost per hour over the leak window, 0 when steady or rising
+	Condition   TireCondition
+	Reported    string // the vehicle's own tyre status, e.g. "UNKNOWN"
+	Updated     time.Time
+}
+
+// TireHealth compares each tire's latest reading with the placard pressures
+// from the vehicle's feature codes, keyed like Tires. A fast leak, measured
+// over the pressure history, takes precedence over a low reading. Tires whose
+// sensor reported BAD_TIRE_PRESSURE, or vehicles without placard features,
+// get TireConditionUnknown.
+func (v *Vehicle) TireHealth(opts TireHealthOptions) map[string]TireHealth {
+	if opts.LowTolerance <= 0 {
+		opts.LowTolerance = DefaultTireLowTolerance
+	}
+	if opts.HighTolerance <= 0 {
+		opts.HighTolerance = DefaultTireHighTolerance
+	}
+	if opts.LeakRate <= 0 {
+		opts.LeakRate = DefaultTireLeakRate
+	}
+	if opts.LeakWindow <= 0 {
+		opts.LeakWindow = DefaultTireLeakWindow
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	placard, hasPlacard := TirePlacardFromFeatures(v.Features)
+	sys := v.unitSystem()
+
+	health := make(map[string]TireHealth, len(v.Tires))
+	for name, t := range v.Tires {
+		h := TireHealth{
+			Position:    t.Position,
+			SubPosition: t.SubPosition,
+			Reported:    t.Status,
+			Updated:     t.Updated,
+		}
+		if hasPlacard {
+			h.Placard = placard.For(t.Position).For(sys)
+		}
+		if t.Reading.Value <= 0 {
+			health[name] = h
+			continue
+		}
+		h.Reading = t.Reading.For(sys)
+		h.LeakRate = tireLeakRate(v.tireHistory[name], opts.LeakWindow)
+		if !hasPlacard {
+			health[name] = h
+			continue
+		}
+		target := placard.For(t.Position).PSI()
+		h.Deviation = (t.Reading.PSI() - target) / target
+		switch {
+		case h.LeakRate >= opts.LeakRate:
+			h.Condition = TireFastLeak
+		case h.Deviation <= -opts.LowTolerance:
+			h.Condition = TireLow
+		case h.Deviation >= opts.HighTolerance:
+			h.Condition = TireHigh
+		default:
+			h.Condition = TireOK
+		}
+		health[name] = h
+	}
+	return health
+}
+
+// tireLeakRate returns the PSI lost per hour between the oldest sample within
+// window of the latest one and the latest one. It is 0 for rising pressure,
+// drops under tireLeakMinDrop and histories shorter than tireLeakMinSpan.
+func tireLeakRate(history []TirePressureSample, window time.Duration) float64 {
+	if len(history) < 2 {
+		return 0
+	}
+	latest := history[len(history)-1]
+	for _, s := range history[:len(history)-1] {
+		span := latest.Time.Sub(s.Time)
+		if span > window {
+			continue
+		}
+		drop := s.Pressure.PSI() - latest.Pressure.PSI()
+		if span < tireLeakMinSpan || drop < tireLeakMinDrop {
+			return 0
+		}
+		return drop / span.Hours()
+	}
+	return 0
+}
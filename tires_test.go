@@ -0,0 +1,125 @@
+package mysubaru
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestTirePlacardFromFeatures verifies placard pressures come from the TIF_
+// and TIR_ feature codes.
+func TestTirePlacardFromFeatures(t *testing.T) {
+	p, ok := TirePlacardFromFeatures([]string{"ABS_MIL", "TIF_35", "TLD", "TIR_33", "g3"})
+	if !ok || p.Front != PressurePSI(35) || p.Rear != PressurePSI(33) || p.For("Rear") != PressurePSI(33) {
+		t.Errorf("expected 35/33 psi, got %+v, %v", p, ok)
+	}
+	if p, _ := TirePlacardFromFeatures([]string{"TIF_240", "TIR_230"}); p.Front != PressureKPa(240) {
+		t.Errorf("expected a kPa placard, got %+v", p)
+	}
+	if _, ok := TirePlacardFromFeatures([]string{"TIF_35", "TIR_x"}); ok {
+		t.Error("expected a missing rear placard to be reported")
+	}
+}
+
+// TestTireHealth verifies readings are compared with the placard and fast
+// leaks are found in the pressure history.
+func TestTireHealth(t *testing.T) {
+	v := &Vehicle{client: &Client{}, Features: []string{"TIF_35", "TIR_33"}, Tires: map[string]Tire{}}
+	v.parseParts("TirePressureFrontLeft", 2413, time.Now())  // 35 psi
+	v.parseParts("TirePressureFrontRight", 2068, time.Now()) // 30 psi
+	v.parseParts("TirePressureRearLeft", 2620, time.Now())   // 38 psi
+	v.parseParts("TirePressureRearRight", 32767, time.Now())
+
+	// The front left lost 4 psi over the last hour.
+	now := v.Tires["tire_front_left"].Updated
+	v.tireHistory["tire_front_left"] = nil
+	v.recordTirePressure("tire_front_left", PressurePSI(40), now.Add(-3*time.Hour))
+	v.recordTirePressure("tire_front_left", PressurePSI(39), now.Add(-time.Hour))
+	v.recordTirePressure("tire_front_left", PressurePSI(37), now.Add(-30*time.Minute))
+	v.recordTirePressure("tire_front_left", PressurePSI(35), now)
+
+	health := v.TireHealth(TireHealthOptions{})
+	tests := []struct {
+		tire string
+		want TireCondition
+	}{
+		{"tire_front_left", TireFastLeak},
+		{"tire_front_right", TireLow},
+		{"tire_rear_left", TireHigh},
+		{"tire_rear_right", TireConditionUnknown},
+	}
+	for _, tt := range tests {
+		if got := health[tt.tire].Condition; got != tt.want {
+			t.Errorf("%s: expected %s, got %s (%+v)", tt.tire, tt.want, got, health[tt.tire])
+		}
+	}
+	if h := health["tire_front_left"]; math.Abs(h.LeakRate-4) > 0.1 || h.Placard != PressurePSI(35) {
+		t.Errorf("expected a 4 psi/h leak against 35 psi, got %+v", h)
+	}
+	if h := health["tire_front_right"]; math.Abs(h.Deviation+0.143) > 0.01 {
+		t.Errorf("expected 14%% below placard, got %v", h.Deviation)
+	}
+
+	if got := v.TireHealth(TireHealthOptions{LeakRate: 5})["tire_front_left"].Condition; got != TireOK {
+		t.Errorf("expected a slower leak than the threshold to be OK, got %s", got)
+	}
+	v.Features = nil
+	if got := v.TireHealth(TireHealthOptions{})["tire_front_right"]; got.Condition != TireConditionUnknown || got.Reading.Value == 0 {
+		t.Errorf("expected an unknown condition with the reading kept, got %+v", got)
+	}
+}
+
+// TestRecordTirePressure verifies close readings merge and old ones expire.
+func TestRecordTirePressure(t *testing.T) {
+	v := &Vehicle{client: &Client{}}
+	start := time.Date(2026, 3, 4, 8, 0, 0, 0, time.UTC)
+	v.recordTirePressure("tire_rear_left", PressurePSI(33), start)
+	v.recordTirePressure("tire_rear_left", PressurePSI(32), start.Add(10*time.Second))
+	v.recordTirePressure("tire_rear_left", PressurePSI(31), start.Add(2*time.Hour))
+	v.recordTirePressure("tire_rear_left", PressurePSI(30), start.Add(25*time.Hour))
+
+	h := v.TirePressureHistory("tire_rear_left")
+	if len(h) != 2 || h[0].Pressure != PressurePSI(31) || h[1].Pressure != PressurePSI(30) {
+		t.Errorf("expected the last two readings, got %+v", h)
+	}
+}
+
+// TestTireHistoryReadingTimes verifies samples are stamped with the time the
+// vehicle reported them, so a stale cached status fetched just before a fresh
+// condition is not taken for a fast leak.
+func TestTireHistoryReadingTimes(t *testing.T) {
+	taken := time.Now().Add(-3 * time.Hour).Truncate(time.Millisecond)
+	fresh := time.Now().Add(-5 * time.Minute).Truncate(time.Second)
+	status := strings.NewReplacer(
+		`"eventDate":1751742945000`, fmt.Sprintf(`"eventDate":%d`, taken.UnixMilli()),
+		`"tirePressureFrontLeft":"2482"`, `"tirePressureFrontLeft":"2413"`, // 35 psi
+	).Replace(testVehicleStatusResponse)
+	condition := strings.NewReplacer(
+		`"lastUpdatedTime":"2025-07-05T19:15:45.000+0000"`, `"lastUpdatedTime":"`+fresh.UTC().Format("2006-01-02T15:04:05.000-0700")+`"`,
+		`"tirePressureFrontLeft":null`, `"tirePressureFrontLeft":33`,
+	).Replace(testConditionResponse)
+	v, _ := setupCountingVehicle(t, false,
+		endpointRoute{Method: http.MethodGet, Path: apiURLs["API_VEHICLE_STATUS"], Response: status},
+		endpointRoute{Method: http.MethodGet, Path: apiURLs["API_CONDITION"], Response: condition},
+	)
+	v.Features = append(v.Features, "TIF_35", "TIR_35")
+
+	ctx := context.Background()
+	for _, fetch := range []func(context.Context) error{v.GetVehicleStatus, v.GetVehicleCondition, v.GetVehicleStatus} {
+		if err := fetch(ctx); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	h := v.TirePressureHistory("tire_front_left")
+	if len(h) != 2 || !h[0].Time.Equal(taken) || !h[1].Time.Equal(fresh) || math.Abs(h[1].Pressure.PSI()-33) > 0.1 {
+		t.Fatalf("expected the stale and fresh readings at their own times, got %+v", h)
+	}
+	if got := v.TireHealth(TireHealthOptions{})["tire_front_left"]; got.Condition == TireFastLeak || !got.Updated.Equal(fresh) {
+		t.Errorf("expected the fresh reading without a fast leak, got %+v", got)
+	}
+}
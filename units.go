@@ -0,0 +1,395 @@
+package mysubaru
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// Units
+// =============================================================================
+
+// UnitSystem selects the units quantities are displayed in.
+type UnitSystem string
+
+const (
+	Imperial UnitSystem = "imperial" // miles, PSI, °F, MPG
+	Metric   UnitSystem = "metric"   // kilometers, kPa, °C, L/100km
+)
+
+// ParseUnitSystem parses a configured unit system. An empty s selects the
+// system customary in region (Metric for CAN, Imperial otherwise).
+func ParseUnitSystem(s, region string) (UnitSystem, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "":
+		if region == "CAN" {
+			return Metric, nil
+		}
+		return Imperial, nil
+	case "imperial", "us":
+		return Imperial, nil
+	case "metric", "si":
+		return Metric, nil
+	}
+	return "", fmt.Errorf("unknown unit system %q", s)
+}
+
+// UnitSystem returns the unit system the client displays quantities in.
+func (c *Client) UnitSystem() UnitSystem {
+	return cmp.Or(c.units, Imperial)
+}
+
+// unitSystem returns the unit system of v's client, Imperial without one.
+func (v *Vehicle) unitSystem() UnitSystem {
+	if v.client == nil {
+		return Imperial
+	}
+	return v.client.UnitSystem()
+}
+
+// formatQuantity formats value, rounded to one decimal, followed by unit.
+func formatQuantity(value float64, unit string) string {
+	return strconv.FormatFloat(math.Round(value*10)/10, 'f', -1, 64) + " " + unit
+}
+
+// validReading reports whether f is a usable reading rather than zero or the
+// 16383 placeholder the API sends for missing distance and fuel values.
+func validReading(f float64) bool {
+	bad, _ := strconv.ParseFloat(BAD_DISTANCE_TO_EMPTY_FUEL, 64)
+	return f > 0 && f != bad
+}
+
+// =============================================================================
+// Distance
+// =============================================================================
+
+// DistanceUnit is the unit of a Distance.
+type DistanceUnit string
+
+const (
+	Miles      DistanceUnit = "mi"
+	Kilometers DistanceUnit = "km"
+)
+
+// Distance is a distance value with its unit.
+type Distance struct {
+	Value float64
+	Unit  DistanceUnit
+}
+
+// DistanceMiles returns a Distance of mi miles.
+func DistanceMiles(mi float64) Distance { return Distance{Value: mi, Unit: Miles} }
+
+// DistanceKm returns a Distance of km kilometers.
+func DistanceKm(km float64) Distance { return Distance{Value: km, Unit: Kilometers} }
+
+// Miles returns the distance in miles.
+func (d Distance) Miles() float64 {
+	if d.Unit == Kilometers {
+		return d.Value / kmPerMile
+	}
+	return d.Value
+}
+
+// Kilometers returns the distance in kilometers.
+func (d Distance) Kilometers() float64 {
+	if d.Unit == Kilometers {
+		return d.Value
+	}
+	return d.Value * kmPerMile
+}
+
+// In returns the distance converted to unit.
+func (d Distance) In(unit DistanceUnit) Distance {
+	if unit == Kilometers {
+		return DistanceKm(d.Kilometers())
+	}
+	return DistanceMiles(d.Miles())
+}
+
+// For returns the distance in the unit of system.
+func (d Distance) For(system UnitSystem) Distance {
+	if system == Metric {
+		return d.In(Kilometers)
+	}
+	return d.In(Miles)
+}
+
+func (d Distance) String() string {
+	return formatQuantity(d.Value, string(cmp.Or(d.Unit, Miles)))
+}
+
+// parseDistanceUnit maps the unit spellings the API uses to a DistanceUnit.
+func parseDistanceUnit(u string) DistanceUnit {
+	switch strings.ToUpper(strings.TrimSpace(u)) {
+	case "KM", "KILOMETERS", "KILOMETRES":
+		return Kilometers
+	}
+	return Miles
+}
+
+// distanceFor picks the reading in the unit of system from a pair of reported
+// readings, converting the other one when it is missing.
+func distanceFor(system UnitSystem, mi, km float64) (Distance, bool) {
+	d, alt := DistanceMiles(mi), DistanceKm(km)
+	if system == Metric {
+		d, alt = alt, d
+	}
+	switch {
+	case validReading(d.Value):
+		return d, true
+	case validReading(alt.Value):
+		return alt.For(system), true
+	}
+	return Distance{}, false
+}
+
+// =============================================================================
+// Pressure
+// =============================================================================
+
+// PressureUnit is the unit of a Pressure.
+type PressureUnit string
+
+const (
+	PSI PressureUnit = "psi"
+	KPa PressureUnit = "kPa"
+	Bar PressureUnit = "bar"
+)
+
+// kPaPerPSI converts between PSI and kPa.
+const kPaPerPSI = 6.894757
+
+// Pressure is a pressure value with its unit.
+type Pressure struct {
+	Value float64
+	Unit  PressureUnit
+}
+
+// PressurePSI returns a Pressure of psi pounds per square inch.
+func PressurePSI(psi float64) Pressure { return Pressure{Value: psi, Unit: PSI} }
+
+// PressureKPa returns a Pressure of kpa kilopascals.
+func PressureKPa(kpa float64) Pressure { return Pressure{Value: kpa, Unit: KPa} }
+
+// PSI returns the pressure in pounds per square inch.
+func (p Pressure) PSI() float64 {
+	if p.Unit == PSI || p.Unit == "" {
+		return p.Value
+	}
+	return p.KPa() / kPaPerPSI
+}
+
+// KPa returns the pressure in kilopascals.
+func (p Pressure) KPa() float64 {
+	switch p.Unit {
+	case KPa:
+		return p.Value
+	case Bar:
+		return p.Value * 100
+	}
+	return p.Value * kPaPerPSI
+}
+
+// Bar returns the pressure in bar.
+func (p Pressure) Bar() float64 { return p.KPa() / 100 }
+
+// In returns the pressure converted to unit.
+func (p Pressure) In(unit PressureUnit) Pressure {
+	switch unit {
+	case KPa:
+		return PressureKPa(p.KPa())
+	case Bar:
+		return Pressure{Value: p.Bar(), Unit: Bar}
+	}
+	return PressurePSI(p.PSI())
+}
+
+// For returns the pressure in the unit of system.
+func (p Pressure) For(system UnitSystem) Pressure {
+	if system == Metric {
+		return p.In(KPa)
+	}
+	return p.In(PSI)
+}
+
+func (p Pressure) String() string {
+	return formatQuantity(p.Value, string(cmp.Or(p.Unit, PSI)))
+}
+
+// parsePressureUnit maps the unit spellings the API uses to a PressureUnit.
+func parsePressureUnit(u string) PressureUnit {
+	switch strings.ToUpper(strings.TrimSpace(u)) {
+	case "KPA":
+		return KPa
+	case "BAR":
+		return Bar
+	}
+	return PSI
+}
+
+// pressureFromRaw converts a status endpoint tire pressure, reported in
+// tenths of a kilopascal (2482 for 36 PSI).
+func pressureFromRaw(raw int) Pressure {
+	return PressureKPa(float64(raw) / 10)
+}
+
+// =============================================================================
+// Temperature
+// =============================================================================
+
+// Temperature is a temperature value with its unit.
+type Temperature struct {
+	Value float64
+	Unit  TemperatureUnit
+}
+
+// TemperatureC returns a Temperature of c degrees Celsius.
+func TemperatureC(c float64) Temperature { return Temperature{Value: c, Unit: Celsius} }
+
+// TemperatureF returns a Temperature of f degrees Fahrenheit.
+func TemperatureF(f float64) Temperature { return Temperature{Value: f, Unit: Fahrenheit} }
+
+// Celsius returns the temperature in degrees Celsius.
+func (t Temperature) Celsius() float64 {
+	if t.Unit == Fahrenheit {
+		return fahrenheitToCelsius(t.Value)
+	}
+	return t.Value
+}
+
+// Fahrenheit returns the temperature in degrees Fahrenheit.
+func (t Temperature) Fahrenheit() float64 {
+	if t.Unit == Fahrenheit {
+		return t.Value
+	}
+	return celsiusToFahrenheit(t.Value)
+}
+
+// In returns the temperature converted to unit.
+func (t Temperature) In(unit TemperatureUnit) Temperature {
+	if unit == Fahrenheit {
+		return TemperatureF(t.Fahrenheit())
+	}
+	return TemperatureC(t.Celsius())
+}
+
+// For returns the temperature in the unit of system.
+func (t Temperature) For(system UnitSystem) Temperature {
+	if system == Metric {
+		return t.In(Celsius)
+	}
+	return t.In(Fahrenheit)
+}
+
+func (t Temperature) String() string {
+	if t.Unit == Celsius {
+		return formatQuantity(t.Value, "°C")
+	}
+	return formatQuantity(t.Value, "°F")
+}
+
+// =============================================================================
+// Fuel Economy
+// =============================================================================
+
+// FuelEconomyUnit is the unit of a FuelEconomy.
+type FuelEconomyUnit string
+
+const (
+	MPG            FuelEconomyUnit = "mpg"
+	LitersPer100Km FuelEconomyUnit = "L/100km"
+)
+
+// mpgTimesLP100Km is the product of a fuel economy in US MPG and the same
+// economy in L/100km.
+const mpgTimesLP100Km = 235.214583
+
+// FuelEconomy is a fuel economy value with its unit.
+type FuelEconomy struct {
+	Value float64
+	Unit  FuelEconomyUnit
+}
+
+// FuelEconomyMPG returns a FuelEconomy of mpg US miles per gallon.
+func FuelEconomyMPG(mpg float64) FuelEconomy { return FuelEconomy{Value: mpg, Unit: MPG} }
+
+// FuelEconomyLP100Km returns a FuelEconomy of l liters per 100 kilometers.
+func FuelEconomyLP100Km(l float64) FuelEconomy {
+	return FuelEconomy{Value: l, Unit: LitersPer100Km}
+}
+
+// MPG returns the fuel economy in US miles per gallon, 0 when unknown.
+func (f FuelEconomy) MPG() float64 {
+	if f.Unit == LitersPer100Km {
+		return invertEconomy(f.Value)
+	}
+	return f.Value
+}
+
+// LP100Km returns the fuel economy in liters per 100 kilometers, 0 when
+// unknown.
+func (f FuelEconomy) LP100Km() float64 {
+	if f.Unit == LitersPer100Km {
+		return f.Value
+	}
+	return invertEconomy(f.Value)
+}
+
+// In returns the fuel economy converted to unit.
+func (f FuelEconomy) In(unit FuelEconomyUnit) FuelEconomy {
+	if unit == LitersPer100Km {
+		return FuelEconomyLP100Km(f.LP100Km())
+	}
+	return FuelEconomyMPG(f.MPG())
+}
+
+// For returns the fuel economy in the unit of system.
+func (f FuelEconomy) For(system UnitSystem) FuelEconomy {
+	if system == Metric {
+		return f.In(LitersPer100Km)
+	}
+	return f.In(MPG)
+}
+
+func (f FuelEconomy) String() string {
+	return formatQuantity(f.Value, string(cmp.Or(f.Unit, MPG)))
+}
+
+// parseFuelEconomyUnit maps the unit spellings the API uses to a
+// FuelEconomyUnit.
+func parseFuelEconomyUnit(u string) FuelEconomyUnit {
+	switch strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(u), " ", "")) {
+	case "L/100KM", "LP100KM", "L100KM":
+		return LitersPer100Km
+	}
+	return MPG
+}
+
+// fuelEconomyFor picks the reading in the unit of system from a pair of
+// reported readings, converting the other one when it is missing.
+func fuelEconomyFor(system UnitSystem, mpg, lp100km float64) (FuelEconomy, bool) {
+	f, alt := FuelEconomyMPG(mpg), FuelEconomyLP100Km(lp100km)
+	if system == Metric {
+		f, alt = alt, f
+	}
+	switch {
+	case validReading(f.Value):
+		return f, true
+	case validReading(alt.Value):
+		return alt.For(system), true
+	}
+	return FuelEconomy{}, false
+}
+
+// invertEconomy converts between MPG and L/100km, which are inversely
+// proportional.
+func invertEconomy(v float64) float64 {
+	if v == 0 {
+		return 0
+	}
+	return mpgTimesLP100Km / v
+}
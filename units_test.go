@@ -0,0 +1,99 @@
+package mysubaru
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// TestQuantityConversions checks conversions and formatting of each quantity.
+func TestQuantityConversions(t *testing.T) {
+	near := func(got, want float64) bool { return math.Abs(got-want) < 0.05 }
+
+	if d := DistanceMiles(100); !near(d.Kilometers(), 160.9) || d.In(Kilometers).String() != "160.9 km" {
+		t.Errorf("unexpected distance conversion: %v, %v", d.Kilometers(), d.In(Kilometers))
+	}
+	if p := pressureFromRaw(2482); !near(p.PSI(), 36) || !near(p.Bar(), 2.48) || p.For(Imperial).String() != "36 psi" {
+		t.Errorf("unexpected pressure conversion: %v psi, %v bar, %v", p.PSI(), p.Bar(), p.For(Imperial))
+	}
+	if tc := TemperatureC(22.5); tc.For(Imperial).String() != "72.5 °F" || !near(TemperatureF(72.5).Celsius(), 22.5) {
+		t.Errorf("unexpected temperature conversion: %v", tc.For(Imperial))
+	}
+	if f := FuelEconomyMPG(30); !near(f.LP100Km(), 7.8) || !near(f.For(Metric).MPG(), 30) || (FuelEconomy{}).LP100Km() != 0 {
+		t.Errorf("unexpected fuel economy conversion: %v", f.For(Metric))
+	}
+}
+
+// TestParseUnitSystem verifies configured and regional unit systems.
+func TestParseUnitSystem(t *testing.T) {
+	tests := []struct {
+		units, region string
+		want          UnitSystem
+	}{
+		{"", "USA", Imperial},
+		{"", "CAN", Metric},
+		{"Metric", "USA", Metric},
+		{"imperial", "CAN", Imperial},
+	}
+	for _, tt := range tests {
+		if got, err := ParseUnitSystem(tt.units, tt.region); err != nil || got != tt.want {
+			t.Errorf("%q/%s: expected %s, got %s, %v", tt.units, tt.region, tt.want, got, err)
+		}
+	}
+	if _, err := ParseUnitSystem("nautical", "USA"); err == nil {
+		t.Error("expected an unknown unit system to be rejected")
+	}
+}
+
+// TestQuantitiesFromReports verifies status and condition readings are
+// normalized to the client's unit system.
+func TestQuantitiesFromReports(t *testing.T) {
+	v := &Vehicle{client: &Client{units: Metric}, Tires: map[string]Tire{}}
+	v.updateVehicleFromStatus(&VehicleStatus{
+		OdometerValue:                 31694,
+		OdometerValueKm:               50996,
+		DistanceToEmptyFuelMiles:      16383,
+		AvgFuelConsumptionMpg:         30,
+		OutsideTemp:                   "18.5",
+		DistanceToEmptyFuelKilometers: 0,
+	})
+	if v.Odometer.Distance != DistanceKm(50996) || v.Odometer.Miles != 31694 {
+		t.Errorf("expected the reported kilometers, got %+v", v.Odometer)
+	}
+	if v.DistanceToEmpty.Distance != (Distance{}) {
+		t.Errorf("expected no range from placeholder readings, got %v", v.DistanceToEmpty.Distance)
+	}
+	if f := v.FuelConsumptionAvg.Economy; f.Unit != LitersPer100Km || math.Abs(f.Value-7.84) > 0.01 {
+		t.Errorf("expected converted L/100km, got %v", f)
+	}
+	if v.OutsideTemp.Temperature != TemperatureC(18.5) {
+		t.Errorf("expected 18.5 °C, got %v", v.OutsideTemp.Temperature)
+	}
+
+	v.parseParts("TirePressureFrontLeft", 2482, time.Now())
+	v.parseParts("TirePressureRearLeft", 32767, time.Now())
+	if tire := v.Tires["tire_front_left"]; tire.Reading != PressureKPa(248.2) {
+		t.Errorf("expected 248.2 kPa, got %v", tire.Reading)
+	}
+	if tire := v.Tires["tire_rear_left"]; tire.Reading != (Pressure{}) || tire.Pressure != 0 {
+		t.Errorf("expected the bad tire pressure to be ignored, got %+v", tire)
+	}
+
+	v.updateVehicleFromCondition(&VehicleCondition{
+		Odometer:                  100,
+		OdometerUnit:              "MILES",
+		DistanceToEmptyFuel:       200,
+		DistanceToEmptyFuelUnit:   "KILOMETERS",
+		TirePressureRearRight:     35,
+		TirePressureRearRightUnit: "PSI",
+	})
+	if v.Odometer.Miles != 100 || v.Odometer.Kilometers != 161 || v.Odometer.Distance.Unit != Kilometers {
+		t.Errorf("expected the condition odometer in kilometers, got %+v", v.Odometer)
+	}
+	if v.DistanceToEmpty.Distance != DistanceKm(200) || v.DistanceToEmpty.Miles != 124 {
+		t.Errorf("expected a 200 km range, got %+v", v.DistanceToEmpty)
+	}
+	if tire := v.Tires["tire_rear_right"]; tire.PressurePsi != 35 || tire.Reading.Unit != KPa || tire.Position != "Rear" {
+		t.Errorf("expected the condition tire pressure in kPa, got %+v", tire)
+	}
+}
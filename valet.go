@@ -0,0 +1,286 @@
+package mysubaru
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// =============================================================================
+// Valet Mode
+// =============================================================================
+
+// ValetModeSettings represents the valet mode configuration
+type ValetModeSettings struct {
+	Enabled         bool    `json:"enabled"`
+	SpeedLimit      int     `json:"speedLimit,omitempty"`
+	SpeedUnit       string  `json:"speedUnit,omitempty"` // MPH or KPH
+	GeoFenceOn      bool    `json:"geoFenceOn,omitempty"`
+	GeoFenceLat     float64 `json:"geoFenceLat,omitempty"`
+	GeoFenceLng     float64 `json:"geoFenceLng,omitempty"`
+	GeoFenceRadius  int     `json:"geoFenceRadius,omitempty"` // in meters
+	AlertOnExit     bool    `json:"notifyOnExit,omitempty"`
+	AlertOnSpeeding bool    `json:"notifyOnSpeedExceed,omitempty"`
+}
+
+// UnmarshalJSON also accepts the field names of the valet setup and settings
+// endpoints (valetModeEnabled, speedLimitUnit, geoFenceEnabled,
+// geoFenceCenterLatitude/Longitude) and converts a geofence radius given in
+// MILES or KM to meters.
+func (s *ValetModeSettings) UnmarshalJSON(data []byte) error {
+	type plain ValetModeSettings
+	var aux struct {
+		plain
+		ValetModeEnabled *bool   `json:"valetModeEnabled"`
+		SpeedLimitUnit   string  `json:"speedLimitUnit"`
+		GeoFenceEnabled  *bool   `json:"geoFenceEnabled"`
+		CenterLatitude   float64 `json:"geoFenceCenterLatitude"`
+		CenterLongitude  float64 `json:"geoFenceCenterLongitude"`
+		RadiusF          float64 `json:"geoFenceRadius"`
+		RadiusUnit       string  `json:"geoFenceRadiusUnit"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*s = ValetModeSettings(aux.plain)
+	if aux.ValetModeEnabled != nil {
+		s.Enabled = *aux.ValetModeEnabled
+	}
+	if aux.GeoFenceEnabled != nil {
+		s.GeoFenceOn = *aux.GeoFenceEnabled
+	}
+	s.SpeedUnit = cmp.Or(s.SpeedUnit, aux.SpeedLimitUnit)
+	s.GeoFenceLat = cmp.Or(s.GeoFenceLat, aux.CenterLatitude)
+	s.GeoFenceLng = cmp.Or(s.GeoFenceLng, aux.CenterLongitude)
+	switch strings.ToUpper(aux.RadiusUnit) {
+	case "MILES", "MI":
+		s.GeoFenceRadius = int(math.Round(aux.RadiusF * 1609.344))
+	case "KM", "KILOMETERS":
+		s.GeoFenceRadius = int(math.Round(aux.RadiusF * 1000))
+	default:
+		s.GeoFenceRadius = int(math.Round(aux.RadiusF))
+	}
+	return nil
+}
+
+// Validate checks the speed limit and, when the valet geofence is on, its
+// center and radius.
+func (s ValetModeSettings) Validate() error {
+	if s.SpeedLimit != 0 {
+		limit := Speed{Value: float64(s.SpeedLimit), Unit: SpeedUnit(strings.ToUpper(s.SpeedUnit))}
+		if err := limit.Validate(); err != nil {
+			return fmt.Errorf("invalid speed limit: %w", err)
+		}
+	}
+	if s.GeoFenceOn {
+		if err := ValidateCoordinates(s.GeoFenceLat, s.GeoFenceLng); err != nil {
+			return fmt.Errorf("invalid geofence center: %w", err)
+		}
+		if err := ValidateGeoFenceRadius(s.GeoFenceRadius); err != nil {
+			return fmt.Errorf("invalid geofence radius: %w", err)
+		}
+	}
+	return nil
+}
+
+// ValetSetup is the valet provisioning state of the vehicle: whether a valet
+// PIN is set in the head unit, and the stored valet settings.
+type ValetSetup struct {
+	PINSet   bool
+	Settings ValetModeSettings
+}
+
+// UnmarshalJSON reads the PIN flag (inVehiclePinSet, valetPinSet or pinSet)
+// and the settings from the same object.
+func (vs *ValetSetup) UnmarshalJSON(data []byte) error {
+	var aux struct {
+		InVehiclePinSet *bool `json:"inVehiclePinSet"`
+		ValetPinSet     *bool `json:"valetPinSet"`
+		PinSet          *bool `json:"pinSet"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*vs = ValetSetup{}
+	for _, b := range []*bool{aux.InVehiclePinSet, aux.ValetPinSet, aux.PinSet} {
+		if b != nil {
+			vs.PINSet = *b
+			break
+		}
+	}
+	return json.Unmarshal(data, &vs.Settings)
+}
+
+// GetValetModeStatus retrieves the current valet mode status
+func (v *Vehicle) GetValetModeStatus(ctx context.Context) (*ValetModeSettings, error) {
+	return v.fetchValetSettings(ctx, "API_VEHICLE_VALET_STATUS", map[string]string{"vin": v.Vin})
+}
+
+// GetValetModeSettings retrieves the valet mode custom settings
+func (v *Vehicle) GetValetModeSettings(ctx context.Context) (*ValetModeSettings, error) {
+	if err := v.requireCapability(CapabilityValet); err != nil {
+		return nil, err
+	}
+	return v.fetchValetSettings(ctx, "API_G2_VALET_SETTINGS_FETCH", map[string]string{})
+}
+
+// GetValetSetup retrieves the valet setup of the vehicle. A vehicle without
+// valet mode provisioned reports a zero ValetSetup.
+func (v *Vehicle) GetValetSetup(ctx context.Context) (*ValetSetup, error) {
+	if err := v.requireCapability(CapabilityValet); err != nil {
+		return nil, err
+	}
+	var raw json.RawMessage
+	if err := v.fetchInto(ctx, GET, "API_G2_VALET_SETUP_FETCH", map[string]string{"vin": v.Vin}, false, &raw); err != nil {
+		return nil, err
+	}
+	if isJSONStringOrNull(raw) {
+		v.client.logger.Debug("valet mode not configured for vehicle; backend returned non-object data",
+			"endpoint", "API_G2_VALET_SETUP_FETCH", "vin", v.Vin, "data", string(raw))
+		return &ValetSetup{}, nil
+	}
+	var setup ValetSetup
+	if err := json.Unmarshal(raw, &setup); err != nil {
+		v.client.logger.Error("error parsing valet setup", "error", err.Error())
+		return nil, err
+	}
+	return &setup, nil
+}
+
+// fetchValetSettings fetches a valet configuration, tolerating backends that
+// return the `data` field as a plain JSON string (or null) for vehicles that
+// don't have valet mode provisioned. Those responses are reported as a disabled
+// (zero-value) configuration rather than surfaced as an unmarshal error.
+func (v *Vehicle) fetchValetSettings(ctx context.Context, urlKey string, params map[string]string) (*ValetModeSettings, error) {
+	var raw json.RawMessage
+	if err := v.fetchInto(ctx, GET, urlKey, params, false, &raw); err != nil {
+		return nil, err
+	}
+	if isJSONStringOrNull(raw) {
+		v.client.logger.Debug("valet mode not configured for vehicle; backend returned non-object data",
+			"endpoint", urlKey, "vin", v.Vin, "data", string(raw))
+		return &ValetModeSettings{}, nil
+	}
+	var settings ValetModeSettings
+	if err := json.Unmarshal(raw, &settings); err != nil {
+		v.client.logger.Error("error parsing valet settings", "endpoint", urlKey, "error", err.Error())
+		return nil, err
+	}
+	return &settings, nil
+}
+
+// ValetModeStart enables valet mode on the vehicle. When no valet PIN is set in
+// the head unit it fails with a ValetSetupError wrapping ErrPINNotSetInHU.
+func (v *Vehicle) ValetModeStart(ctx context.Context) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		"delay":  "0",
+		"vin":    v.Vin,
+		"pin":    pin,
+		"action": "start",
+	}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityValet, "API_G2_VALET_MODE")
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := v.actuateChecked(ctx, params, reqUrl, pollingUrl)
+	return ch, valetSetupError(err)
+}
+
+// ValetModeStop disables valet mode on the vehicle
+func (v *Vehicle) ValetModeStop(ctx context.Context) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		"delay":  "0",
+		"vin":    v.Vin,
+		"pin":    pin,
+		"action": "stop",
+	}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityValet, "API_G2_VALET_MODE")
+	if err != nil {
+		return nil, err
+	}
+
+	return v.actuate(ctx, params, reqUrl, pollingUrl)
+}
+
+// SaveValetModeSettings validates and saves custom valet mode settings,
+// including the valet geofence.
+func (v *Vehicle) SaveValetModeSettings(ctx context.Context, settings ValetModeSettings) (chan string, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		"vin":                 v.Vin,
+		"pin":                 pin,
+		"speedLimit":          strconv.Itoa(settings.SpeedLimit),
+		"speedUnit":           settings.SpeedUnit,
+		"geoFenceOn":          strconv.FormatBool(settings.GeoFenceOn),
+		"notifyOnExit":        strconv.FormatBool(settings.AlertOnExit),
+		"notifyOnSpeedExceed": strconv.FormatBool(settings.AlertOnSpeeding),
+	}
+	if settings.GeoFenceOn {
+		params["geoFenceLat"] = fmt.Sprintf("%.6f", settings.GeoFenceLat)
+		params["geoFenceLng"] = fmt.Sprintf("%.6f", settings.GeoFenceLng)
+		params["geoFenceRadius"] = strconv.Itoa(settings.GeoFenceRadius)
+	}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityValet, "API_G2_VALET_SETTINGS_SAVE")
+	if err != nil {
+		return nil, err
+	}
+
+	return v.actuate(ctx, params, reqUrl, pollingUrl)
+}
+
+// ResetValetPIN sets a new 4-digit valet PIN in the vehicle's head unit.
+func (v *Vehicle) ResetValetPIN(ctx context.Context, valetPIN string) (chan string, error) {
+	if err := ValidatePIN(valetPIN); err != nil {
+		return nil, fmt.Errorf("invalid valet PIN: %w", err)
+	}
+
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		"delay":    "0",
+		"vin":      v.Vin,
+		"pin":      pin,
+		"valetPin": valetPIN,
+	}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityValet, "API_G2_VALET_PIN_RESET")
+	if err != nil {
+		return nil, err
+	}
+
+	return v.actuateChecked(ctx, params, reqUrl, pollingUrl)
+}
+
+// valetSetupError wraps ErrPINNotSetInHU in a ValetSetupError with the steps
+// to fix it; other errors are returned unchanged.
+func valetSetupError(err error) error {
+	if errors.Is(err, ErrPINNotSetInHU) {
+		return ValetSetupError{
+			Err:  err,
+			Hint: "set a valet PIN with ResetValetPIN or in the vehicle's head unit (Settings > Valet Mode), then start valet mode again",
+		}
+	}
+	return err
+}
@@ -0,0 +1,87 @@
+package mysubaru
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"testing"
+)
+
+// TestValetModeSettings_Fixture reads the valet settings fixture, whose field
+// names differ from the save parameters, including the radius in miles.
+func TestValetModeSettings_Fixture(t *testing.T) {
+	data := loadFixture(t, "valetModeSettings.json")
+	var resp struct {
+		Data ValetModeSettings `json:"data"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	s := resp.Data
+	if !s.Enabled || s.SpeedLimit != 65 || s.SpeedUnit != "MPH" || !s.GeoFenceOn ||
+		s.GeoFenceLat != 40.7128 || s.GeoFenceRadius != 8047 || !s.AlertOnExit || !s.AlertOnSpeeding {
+		t.Errorf("unexpected settings: %+v", s)
+	}
+	if err := s.Validate(); err != nil {
+		t.Errorf("expected fixture settings to validate, got %v", err)
+	}
+
+	var setup ValetSetup
+	if err := json.Unmarshal([]byte(`{"inVehiclePinSet":true,"valetModeEnabled":false,"speedLimit":50,"speedLimitUnit":"KPH"}`), &setup); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !setup.PINSet || setup.Settings.SpeedLimit != 50 || setup.Settings.SpeedUnit != "KPH" {
+		t.Errorf("unexpected setup: %+v", setup)
+	}
+}
+
+// TestValetModeSettings_Validate verifies invalid settings are rejected before
+// any request is made.
+func TestValetModeSettings_Validate(t *testing.T) {
+	v := &Vehicle{client: &Client{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}}
+
+	bad := []ValetModeSettings{
+		{SpeedLimit: 300, SpeedUnit: "KPH"},
+		{GeoFenceOn: true, GeoFenceLat: 95, GeoFenceLng: 0, GeoFenceRadius: 500},
+		{GeoFenceOn: true, GeoFenceLat: 40, GeoFenceLng: -74, GeoFenceRadius: 10},
+	}
+	for _, s := range bad {
+		if _, err := v.SaveValetModeSettings(context.Background(), s); err == nil {
+			t.Errorf("expected %+v to be rejected", s)
+		}
+	}
+	if _, err := v.ResetValetPIN(context.Background(), "12a4"); err == nil {
+		t.Error("expected non-numeric valet PIN to be rejected")
+	}
+}
+
+// TestValetModeStart_PINNotSet verifies the head unit's missing-PIN rejection
+// comes back as a guided ValetSetupError.
+func TestValetModeStart_PINNotSet(t *testing.T) {
+	routes := append(standardTestRoutes(),
+		endpointRoute{Method: http.MethodPost, Path: apiURLs["API_G2_VALET_MODE"], Response: `{"success":false,"errorCode":"NegativeAcknowledge_pinNotSetInHeadUnit","dataName":null,"data":null}`},
+	)
+	ts := mockServerWithRoutes(t, routes)
+	ts.Start()
+	defer ts.Close()
+
+	msc, err := New(mockConfig(t))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if ok, _, err := msc.Authenticate(context.Background()); !ok || err != nil {
+		t.Fatalf("expected authentication to succeed, got ok=%v, err=%v", ok, err)
+	}
+	v, err := msc.GetVehicleByVin(context.Background(), "1HGCM82633A004352")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err = v.ValetModeStart(context.Background())
+	if !IsValetSetupError(err) || !errors.Is(err, ErrPINNotSetInHU) {
+		t.Fatalf("expected ValetSetupError wrapping ErrPINNotSetInHU, got %v", err)
+	}
+}
@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"reflect"
 	"regexp"
 	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -31,58 +34,42 @@ const (
 // Vehicle represents a Subaru vehicle with various attributes and methods to interact with it.
 type Vehicle struct {
 	CarId                int64
-	Vin                  string   // SELECT CAR REQUEST > "vin": "4S4BTGND8L3137058"
-	CarName              string   // SELECT CAR REQUEST > "vehicleName": "Subaru Outback LXT"
-	CarNickname          string   // SELECT CAR REQUEST > "nickname": "Subaru Outback LXT"
-	ExtDescrip           string   // SELECT CAR REQUEST > "extDescrip": "Abyss Blue Pearl"
-	IntDescrip           string   // SELECT CAR REQUEST > "intDescrip": "Gray"
-	ModelName            string   // SELECT CAR REQUEST > "modelName": "Outback",
-	ModelYear            string   // SELECT CAR REQUEST > "modelYear": "2020"
-	ModelCode            string   // SELECT CAR REQUEST > "modelCode": "LDJ"
-	TransCode            string   // SELECT CAR REQUEST > "transCode": "CVT"
-	EngineSize           float64  // SELECT CAR REQUEST > "engineSize": 2.4
-	VehicleKey           int64    // SELECT CAR REQUEST > "vehicleKey": 3832950
-	LicensePlate         string   // SELECT CAR REQUEST > "licensePlate": "8KV8"
-	LicensePlateState    string   // SELECT CAR REQUEST > "licensePlateState": "NJ"
-	Features             []string // SELECT CAR REQUEST > "features": ["ATF_MIL","11.6MMAN","ABS_MIL","CEL_MIL","ACCS","RCC","REARBRK","TEL_MIL","VDC_MIL","TPMS_MIL","WASH_MIL","BSDRCT_MIL","OPL_MIL","EYESIGHT","RAB_MIL","SRS_MIL","ESS_MIL","RESCC","EOL_MIL","BSD","EBD_MIL","EPB_MIL","RES","RHSF","AWD_MIL","NAV_TOMTOM","ISS_MIL","RPOIA","EPAS_MIL","RPOI","AHBL_MIL","SRH_MIL","g2"],
-	SubscriptionFeatures []string // SELECT CAR REQUEST > "subscriptionFeatures": ["REMOTE","SAFETY","Retail"]
-	SubscriptionStatus   string   // SELECT CAR REQUEST > "subscriptionStatus": "ACTIVE"
-	EngineState          string   // STATUS REQUEST     > "vehicleStateType": "IGNITION_OFF"
-	Odometer             struct {
-		Miles      int // STATUS REQUEST > "odometerValue": 24999
-		Kilometers int // STATUS REQUEST > "odometerValueKilometers": 40223
-	}
-	DistanceToEmpty struct {
-		Miles         int // STATUS REQUEST > "distanceToEmptyFuelMiles": 149.75
-		Kilometers    int // STATUS REQUEST > "distanceToEmptyFuelKilometers": 241
-		Miles10s      int // STATUS REQUEST > "distanceToEmptyFuelMiles10s": 150
-		Kilometers10s int // STATUS REQUEST > "distanceToEmptyFuelKilometers10s": 240
-		Percentage    int // > "remainingFuelPercent": 66
-	}
-	FuelConsumptionAvg struct {
-		MPG     float64 // STATUS REQUEST > "avgFuelConsumptionMpg": 18.5
-		LP100Km float64 // STATUS REQUEST > "avgFuelConsumptionLitersPer100Kilometers": 12.7
-	}
-	ClimateProfiles map[string]ClimateProfile
-	Doors           map[string]Door    // CONDITION REQUEST >
-	Windows         map[string]Window  // CONDITION REQUEST >
-	Tires           map[string]Tire    // CONDITION AND STATUS REQUEST >
-	Troubles        map[string]Trouble //
-	GeoLocation     GeoLocation
-	// EV-specific fields
-	EVStatus struct {
-		StateOfChargePercent        int    // Battery charge percentage (0-100)
-		DistanceToEmptyMiles        int    // Electric range in miles
-		DistanceToEmptyKm           int    // Electric range in kilometers
-		DistanceToEmptyByStateMiles int    // Electric range by state in miles
-		DistanceToEmptyByStateKm    int    // Electric range by state in kilometers
-		IsPluggedIn                 bool   // Whether vehicle is plugged in
-		ChargerStateType            string // Charger state (e.g., "CHARGING", "NOT_CHARGING")
-		StateOfChargeMode           string // Charge mode
-		TimeToFullyCharged          string // Time remaining to full charge
-	}
-	Updated time.Time
-	client  *Client
+	Vin                  string        // SELECT CAR REQUEST > "vin": "4S4BTGND8L3137058"
+	CarName              string        // SELECT CAR REQUEST > "vehicleName": "Subaru Outback LXT"
+	CarNickname          string        // SELECT CAR REQUEST > "nickname": "Subaru Outback LXT"
+	ExtDescrip           string        // SELECT CAR REQUEST > "extDescrip": "Abyss Blue Pearl"
+	IntDescrip           string        // SELECT CAR REQUEST > "intDescrip": "Gray"
+	ModelName            string        // SELECT CAR REQUEST > "modelName": "Outback",
+	ModelYear            string        // SELECT CAR REQUEST > "modelYear": "2020"
+	ModelCode            string        // SELECT CAR REQUEST > "modelCode": "LDJ"
+	TransCode            string        // SELECT CAR REQUEST > "transCode": "CVT"
+	EngineSize           float64       // SELECT CAR REQUEST > "engineSize": 2.4
+	VehicleKey           int64         // SELECT CAR REQUEST > "vehicleKey": 3832950
+	LicensePlate         string        // SELECT CAR REQUEST > "licensePlate": "8KV8"
+	LicensePlateState    string        // SELECT CAR REQUEST > "licensePlateState": "NJ"
+	Features             []string      // SELECT CAR REQUEST > "features": ["ATF_MIL","11.6MMAN","ABS_MIL","CEL_MIL","ACCS","RCC","REARBRK","TEL_MIL","VDC_MIL","TPMS_MIL","WASH_MIL","BSDRCT_MIL","OPL_MIL","EYESIGHT","RAB_MIL","SRS_MIL","ESS_MIL","RESCC","EOL_MIL","BSD","EBD_MIL","EPB_MIL","RES","RHSF","AWD_MIL","NAV_TOMTOM","ISS_MIL","RPOIA","EPAS_MIL","RPOI","AHBL_MIL","SRH_MIL","g2"],
+	SubscriptionFeatures []string      // SELECT CAR REQUEST > "subscriptionFeatures": ["REMOTE","SAFETY","Retail"]
+	SubscriptionStatus   string        // SELECT CAR REQUEST > "subscriptionStatus": "ACTIVE"
+	TimeZone             string        // SELECT CAR REQUEST > "timeZone": "America/New_York"
+	StolenVehicle        bool          // SELECT CAR REQUEST > "stolenVehicle": false
+	EngineState          IgnitionState // STATUS REQUEST     > "vehicleStateType": "IGNITION_OFF"
+	Odometer             Odometer
+	DistanceToEmpty      DistanceToEmpty
+	FuelConsumptionAvg   FuelConsumption
+	OutsideTemp          OutsideTemperature
+	ClimateProfiles      map[string]ClimateProfile
+	Doors                map[string]Door    // CONDITION REQUEST >
+	Windows              map[string]Window  // CONDITION REQUEST >
+	Tires                map[string]Tire    // CONDITION AND STATUS REQUEST >
+	Troubles             map[string]Trouble // HEALTH REQUEST > nil until the first report
+	GeoLocation          GeoLocation
+	EVStatus             EVStatus // EV-specific fields
+	Updated              time.Time
+	client               *Client
+
+	// climateBands maps outside temperatures to climate profiles for
+	// ClimateProfileAuto; set with SetClimateBands.
+	climateBands []ClimateBand
 
 	// mu guards concurrent access to this vehicle's mutable state (the maps,
 	// GeoLocation, EVStatus, Updated, etc.). Polling, location updates, and
@@ -91,6 +78,18 @@ type Vehicle struct {
 	// unexported value field, so it is never serialized and the zero value is
 	// ready to use.
 	mu sync.RWMutex
+
+	// tireHistory holds each tire's recent pressure readings, keyed like
+	// Tires, for TireHealth's leak detection.
+	tireHistory map[string][]TirePressureSample
+
+	// commandsInFlight counts remote commands still awaiting their final
+	// state; a Poller polls faster while it is non-zero.
+	commandsInFlight atomic.Int32
+
+	// missingEndpoints records generation-specific URLs the backend answered
+	// with ErrEndpointNotFound, so execute goes straight to the fallback.
+	missingEndpoints sync.Map
 }
 
 // MarshalJSON provides custom JSON serialization for Vehicle.
@@ -113,20 +112,96 @@ func (v *Vehicle) MarshalJSON() ([]byte, error) {
 	})
 }
 
+// Odometer is the vehicle's odometer reading.
+type Odometer struct {
+	Miles      int      // STATUS REQUEST > "odometerValue": 24999
+	Kilometers int      // STATUS REQUEST > "odometerValueKilometers": 40223
+	Distance   Distance // in the client's unit system
+	Updated    time.Time
+}
+
+// DistanceToEmpty is the vehicle's fuel level and remaining fuel range.
+type DistanceToEmpty struct {
+	Miles         int      // STATUS REQUEST > "distanceToEmptyFuelMiles": 149.75
+	Kilometers    int      // STATUS REQUEST > "distanceToEmptyFuelKilometers": 241
+	Miles10s      int      // STATUS REQUEST > "distanceToEmptyFuelMiles10s": 150
+	Kilometers10s int      // STATUS REQUEST > "distanceToEmptyFuelKilometers10s": 240
+	Percentage    int      // > "remainingFuelPercent": 66
+	Distance      Distance // in the client's unit system
+	Updated       time.Time
+}
+
+// FuelConsumption is the vehicle's average fuel economy.
+type FuelConsumption struct {
+	MPG     float64     // STATUS REQUEST > "avgFuelConsumptionMpg": 18.5
+	LP100Km float64     // STATUS REQUEST > "avgFuelConsumptionLitersPer100Kilometers": 12.7
+	Economy FuelEconomy // in the client's unit system
+}
+
+// OutsideTemperature is the outside temperature the vehicle last reported.
+type OutsideTemperature struct {
+	Celsius     float64 // STATUS/CONDITION REQUEST > "outsideTemp": "22.0" (EXT_EXTERNAL_TEMP)
+	Fahrenheit  float64
+	Temperature Temperature // in the client's unit system
+	Valid       bool        // false when the reading is missing or BAD_EXTERNAL_TEMP
+}
+
+// EVStatus is the battery and charging state of a PHEV.
+type EVStatus struct {
+	StateOfChargePercent        int            // Battery charge percentage (0-100)
+	DistanceToEmptyMiles        int            // Electric range in miles
+	DistanceToEmptyKm           int            // Electric range in kilometers
+	DistanceToEmptyByStateMiles int            // Electric range by state in miles
+	DistanceToEmptyByStateKm    int            // Electric range by state in kilometers
+	Range                       Distance       // Electric range in the client's unit system
+	IsPluggedIn                 bool           // Whether vehicle is plugged in
+	ChargerStateType            ChargerState   // Charger state (e.g., "CHARGING", "NOT_CHARGING")
+	StateOfChargeMode           string         // Charge mode
+	TimeToFullyCharged          string         // Time remaining to full charge
+	TimeToFullyChargedUTC       string         // Estimated full-charge time (EV_TIME_TO_FULLY_CHARGED_UTC)
+	ChargeSettings              ChargeSettings // Charge timer schedules (GetEVChargeSettings)
+}
+
 // Door represents a door of a Subaru vehicle with its position, sub-position, status, and lock state.
 type Door struct {
-	Position    string // front | rear | boot | enginehood
-	SubPosition string // right | left
-	Status      string // CLOSED | OPEN
-	Lock        string // LOCKED | UNLOCKED
+	Position    string     // front | rear | boot | enginehood
+	SubPosition string     // right | left
+	Status      DoorStatus // CLOSED | OPEN
+	Lock        LockStatus // LOCKED | UNLOCKED
 	Updated     time.Time
 }
 
+// UnlockDoor selects which doors a remote unlock command releases. The values
+// are the wire values of the unlockDoorType parameter.
+type UnlockDoor string
+
+const (
+	UnlockAllDoors   UnlockDoor = ALL_DOORS
+	UnlockDriverDoor UnlockDoor = DRIVERS_DOOR
+	UnlockTailgate   UnlockDoor = TAILGATE_DOOR
+)
+
+// checkUnlockDoor validates an unlock target against a vehicle's feature
+// codes. The tailgate target needs the RTGU (remote rear gate unlock) feature.
+func checkUnlockDoor(door UnlockDoor, codes []string) error {
+	switch door {
+	case UnlockAllDoors, UnlockDriverDoor:
+		return nil
+	case UnlockTailgate:
+		if !slices.Contains(codes, FEATURE_TAILGATE) {
+			return UnsupportedFeatureError{Feature: FEATURE_TAILGATE, Message: features[FEATURE_TAILGATE]}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown unlock door type %q", string(door))
+	}
+}
+
 // Window represents a window of a Subaru vehicle with its position, sub-position, status, and last updated time.
 type Window struct {
 	Position    string
 	SubPosition string
-	Status      string // CLOSE | VENTED | OPEN
+	Status      WindowStatus // CLOSE | VENTED | SLIDE_PARTLY_OPEN | OPEN
 	Updated     time.Time
 }
 
@@ -134,10 +209,11 @@ type Window struct {
 type Tire struct {
 	Position    string
 	SubPosition string
-	Pressure    int
-	PressurePsi int
+	Pressure    int      // tenths of a kPa, e.g. 2482
+	PressurePsi int      // e.g. 36
+	Reading     Pressure // in the client's unit system
+	Status      string   // STATUS REQUEST > "tyreStatusFrontLeft": "UNKNOWN"
 	Updated     time.Time
-	// Status string
 }
 
 // Trouble represents a trouble or issue with a Subaru vehicle, containing a description of the trouble.
@@ -187,6 +263,7 @@ func normalizeClimateProfile(rp map[string]any) ClimateProfile {
 		HeatedSeatFrontRight:      toString(rp["heatedSeatFrontRight"]),
 		HeatedSeatFrontLeft:       toString(rp["heatedSeatFrontLeft"]),
 		ClimateZoneFrontTemp:      toInt(rp["climateZoneFrontTemp"]),
+		ClimateZoneFrontTempC:     toInt(rp["climateZoneFrontTempCelsius"]),
 		ClimateZoneFrontAirMode:   toString(rp["climateZoneFrontAirMode"]),
 		ClimateZoneFrontAirVolume: toString(rp["climateZoneFrontAirVolume"]),
 		OuterAirCirculation:       toString(rp["outerAirCirculation"]),
@@ -226,7 +303,7 @@ func (v *Vehicle) String() string {
 		vString += "Range by State (Miles): " + fmt.Sprintf("%d", v.EVStatus.DistanceToEmptyByStateMiles) + "\n"
 		vString += "Range by State (Km): " + fmt.Sprintf("%d", v.EVStatus.DistanceToEmptyByStateKm) + "\n"
 		vString += "Plugged In: " + fmt.Sprintf("%t", v.EVStatus.IsPluggedIn) + "\n"
-		vString += "Charger State: " + v.EVStatus.ChargerStateType + "\n"
+		vString += "Charger State: " + v.EVStatus.ChargerStateType.String() + "\n"
 		vString += "Charge Mode: " + v.EVStatus.StateOfChargeMode + "\n"
 		vString += "Time to Full Charge: " + v.EVStatus.TimeToFullyCharged + "\n"
 	}
@@ -277,27 +354,50 @@ func (v *Vehicle) String() string {
 // Lock
 // Sends a command to lock doors.
 func (v *Vehicle) Lock(ctx context.Context) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"delay":         "0",
 		"vin":           v.Vin,
-		"pin":           v.client.credentials.PIN,
+		"pin":           pin,
 		"forceKeyInCar": "false"}
-	reqUrl := MOBILE_API_VERSION + urlToGen(apiURLs["API_LOCK"], v.getAPIGen())
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityLock, "API_LOCK")
+	if err != nil {
+		return nil, err
+	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
 }
 
 // Unlock
-// Send command to unlock doors.
+// Send command to unlock all doors.
 func (v *Vehicle) Unlock(ctx context.Context) (chan string, error) {
+	return v.UnlockDoors(ctx, UnlockAllDoors)
+}
+
+// UnlockDoors
+// Send command to unlock the selected doors (all, driver's door or tailgate).
+// Returns an UnsupportedFeatureError when the vehicle lacks the capability.
+func (v *Vehicle) UnlockDoors(ctx context.Context, door UnlockDoor) (chan string, error) {
+	if err := checkUnlockDoor(door, v.Features); err != nil {
+		return nil, err
+	}
+
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
-		"delay":          "0",
-		"vin":            v.Vin,
-		"pin":            v.client.credentials.PIN,
-		"unlockDoorType": "ALL_DOORS_CMD"} // FRONT_LEFT_DOOR_CMD | ALL_DOORS_CMD
-	reqUrl := MOBILE_API_VERSION + urlToGen(apiURLs["API_UNLOCK"], v.getAPIGen())
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
+		"delay":    "0",
+		"vin":      v.Vin,
+		"pin":      pin,
+		WHICH_DOOR: string(door)}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityLock, "API_UNLOCK")
+	if err != nil {
+		return nil, err
+	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
 }
@@ -310,6 +410,7 @@ func (v *Vehicle) EngineStart(ctx context.Context, run, delay int, horn bool) (c
 
 // EngineStartWithProfile starts the engine using either a selected climate profile or defaults.
 // If profileName matches an entry in ClimateProfiles, its values override defaults.
+// ClimateProfileAuto picks the profile from the outside temperature (see SetClimateBands).
 func (v *Vehicle) EngineStartWithProfile(ctx context.Context, run, delay int, horn bool, profileName string) (chan string, error) {
 	// Validate run time parameter
 	validRunTimes := []int{0, 1, 5, 10}
@@ -328,10 +429,14 @@ func (v *Vehicle) EngineStartWithProfile(ctx context.Context, run, delay int, ho
 		startConfig = START_CONFIG_DEFAULT_EV
 	}
 
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"delay":                     strconv.Itoa(delay),
 		"vin":                       v.Vin,
-		"pin":                       v.client.credentials.PIN,
+		"pin":                       pin,
 		"horn":                      strconv.FormatBool(horn),
 		"climateSettings":           "climateSettings",
 		"climateZoneFrontTemp":      DefaultClimateTemp,
@@ -346,18 +451,27 @@ func (v *Vehicle) EngineStartWithProfile(ctx context.Context, run, delay int, ho
 		"startConfiguration":        startConfig,
 	}
 
+	if profileName == ClimateProfileAuto {
+		profileName = v.autoClimateProfile()
+	}
+
 	// Apply profile overrides if present
 	if profileName != "" {
 		v.mu.RLock()
 		cp, ok := v.ClimateProfiles[profileName]
 		v.mu.RUnlock()
 		if ok {
+			if err := v.validateClimateProfile(cp); err != nil {
+				return nil, err
+			}
 			applyClimateProfile(params, cp)
 		}
 	}
 
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_REMOTE_ENGINE_START"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityEngineStart, "API_G2_REMOTE_ENGINE_START")
+	if err != nil {
+		return nil, err
+	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
 }
@@ -365,7 +479,12 @@ func (v *Vehicle) EngineStartWithProfile(ctx context.Context, run, delay int, ho
 // applyClimateProfile applies climate profile settings to the params map.
 func applyClimateProfile(params map[string]string, cp ClimateProfile) {
 	if cp.ClimateZoneFrontTemp != 0 {
-		params["climateZoneFrontTemp"] = strconv.Itoa(cp.ClimateZoneFrontTemp)
+		params[TEMP_F] = strconv.Itoa(cp.ClimateZoneFrontTemp)
+	}
+	if cp.ClimateZoneFrontTempC != 0 {
+		// Celsius replaces the Fahrenheit default rather than being sent alongside it
+		delete(params, TEMP_F)
+		params[TEMP_C] = strconv.Itoa(cp.ClimateZoneFrontTempC)
 	}
 	if cp.ClimateZoneFrontAirMode != "" {
 		params["climateZoneFrontAirMode"] = cp.ClimateZoneFrontAirMode
@@ -392,19 +511,25 @@ func applyClimateProfile(params map[string]string, cp ClimateProfile) {
 		params["runTimeMinutes"] = strconv.Itoa(cp.RunTimeMinutes)
 	}
 	if cp.StartConfiguration != "" {
-		params["startConfiguration"] = cp.StartConfiguration
+		params[START_CONFIG] = cp.StartConfiguration
 	}
 }
 
 // EngineStop
 // Sends a command to stop engine.
 func (v *Vehicle) EngineStop(ctx context.Context) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"delay": "0",
 		"vin":   v.Vin,
-		"pin":   v.client.credentials.PIN}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_REMOTE_ENGINE_STOP"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
+		"pin":   pin}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityEngineStart, "API_G2_REMOTE_ENGINE_STOP")
+	if err != nil {
+		return nil, err
+	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
 }
@@ -412,14 +537,17 @@ func (v *Vehicle) EngineStop(ctx context.Context) (chan string, error) {
 // LightsStart
 // Sends a command to flash lights.
 func (v *Vehicle) LightsStart(ctx context.Context) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"delay": "0",
 		"vin":   v.Vin,
-		"pin":   v.client.credentials.PIN}
-	reqUrl := MOBILE_API_VERSION + urlToGen(apiURLs["API_LIGHTS"], v.getAPIGen())
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-	if v.getAPIGen() == FEATURE_G1_TELEMATICS {
-		pollingUrl = MOBILE_API_VERSION + apiURLs["API_G1_HORN_LIGHTS_STATUS"]
+		"pin":   pin}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityHornLights, "API_LIGHTS")
+	if err != nil {
+		return nil, err
 	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
@@ -428,60 +556,101 @@ func (v *Vehicle) LightsStart(ctx context.Context) (chan string, error) {
 // LightsStop
 // Sends a command to stop flash lights.
 func (v *Vehicle) LightsStop(ctx context.Context) (chan string, error) {
-	params := map[string]string{
-		"delay": "0",
-		"vin":   v.Vin,
-		"pin":   v.client.credentials.PIN}
-	reqUrl := MOBILE_API_VERSION + urlToGen(apiURLs["API_LIGHTS_STOP"], v.getAPIGen())
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-	if v.getAPIGen() == FEATURE_G1_TELEMATICS {
-		pollingUrl = MOBILE_API_VERSION + apiURLs["API_G1_HORN_LIGHTS_STATUS"]
-	}
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
+	return v.LightsStopByID(ctx, "")
 }
 
 // HornStart
-// Send command to sound horn.
+// Send command to sound horn. The backend only exposes a combined horn and
+// lights command, so this is equivalent to HornLightsStart.
 func (v *Vehicle) HornStart(ctx context.Context) (chan string, error) {
+	return v.HornLightsStart(ctx)
+}
+
+// HornLightsStart
+// Send command to sound the horn and flash the lights.
+func (v *Vehicle) HornLightsStart(ctx context.Context) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"delay": "0",
 		"vin":   v.Vin,
-		"pin":   v.client.credentials.PIN}
-	reqUrl := MOBILE_API_VERSION + urlToGen(apiURLs["API_HORN_LIGHTS"], v.getAPIGen())
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-	if v.getAPIGen() == FEATURE_G1_TELEMATICS {
-		pollingUrl = MOBILE_API_VERSION + apiURLs["API_G1_HORN_LIGHTS_STATUS"]
+		"pin":   pin}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityHornLights, "API_HORN_LIGHTS")
+	if err != nil {
+		return nil, err
 	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
 }
 
 // HornStop
-// Send command to sound horn.
+// Send command to stop the horn.
 func (v *Vehicle) HornStop(ctx context.Context) (chan string, error) {
+	return v.HornLightsStopByID(ctx, "")
+}
+
+// HornLightsStopByID
+// Send command to stop a running horn and lights request. serviceRequestID
+// identifies the request to stop; pass "" to stop whatever is running.
+func (v *Vehicle) HornLightsStopByID(ctx context.Context, serviceRequestID string) (chan string, error) {
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityHornLights, "API_HORN_LIGHTS_STOP")
+	if err != nil {
+		return nil, err
+	}
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.actuate(ctx, v.stopParams(serviceRequestID, pin), reqUrl, pollingUrl)
+}
+
+// LightsStopByID
+// Send command to stop a running lights-only request. serviceRequestID
+// identifies the request to stop; pass "" to stop whatever is running.
+func (v *Vehicle) LightsStopByID(ctx context.Context, serviceRequestID string) (chan string, error) {
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityHornLights, "API_LIGHTS_STOP")
+	if err != nil {
+		return nil, err
+	}
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.actuate(ctx, v.stopParams(serviceRequestID, pin), reqUrl, pollingUrl)
+}
+
+// stopParams builds the parameters of a horn/lights stop command, carrying
+// the service request ID of the request being stopped when known.
+func (v *Vehicle) stopParams(serviceRequestID, pin string) map[string]string {
 	params := map[string]string{
 		"delay": "0",
 		"vin":   v.Vin,
-		"pin":   v.client.credentials.PIN}
-	reqUrl := MOBILE_API_VERSION + urlToGen(apiURLs["API_HORN_LIGHTS_STOP"], v.getAPIGen())
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-	if v.getAPIGen() == FEATURE_G1_TELEMATICS {
-		pollingUrl = MOBILE_API_VERSION + apiURLs["API_G1_HORN_LIGHTS_STATUS"]
+		"pin":   pin}
+	if serviceRequestID != "" {
+		params[SERVICE_REQ_ID] = serviceRequestID
 	}
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
+	return params
 }
 
 // LockCancel
 // Cancel an ongoing lock operation.
 func (v *Vehicle) LockCancel(ctx context.Context) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"delay": "0",
 		"vin":   v.Vin,
-		"pin":   v.client.credentials.PIN}
-	reqUrl := MOBILE_API_VERSION + urlToGen(apiURLs["API_LOCK_CANCEL"], v.getAPIGen())
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
+		"pin":   pin}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityLock, "API_LOCK_CANCEL")
+	if err != nil {
+		return nil, err
+	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
 }
@@ -489,12 +658,18 @@ func (v *Vehicle) LockCancel(ctx context.Context) (chan string, error) {
 // UnlockCancel
 // Cancel an ongoing unlock operation.
 func (v *Vehicle) UnlockCancel(ctx context.Context) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"delay": "0",
 		"vin":   v.Vin,
-		"pin":   v.client.credentials.PIN}
-	reqUrl := MOBILE_API_VERSION + urlToGen(apiURLs["API_UNLOCK_CANCEL"], v.getAPIGen())
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
+		"pin":   pin}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityLock, "API_UNLOCK_CANCEL")
+	if err != nil {
+		return nil, err
+	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
 }
@@ -502,12 +677,18 @@ func (v *Vehicle) UnlockCancel(ctx context.Context) (chan string, error) {
 // EngineStartCancel
 // Cancel an ongoing engine start operation.
 func (v *Vehicle) EngineStartCancel(ctx context.Context) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"delay": "0",
 		"vin":   v.Vin,
-		"pin":   v.client.credentials.PIN}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_REMOTE_ENGINE_START_CANCEL"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
+		"pin":   pin}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityEngineStart, "API_G2_REMOTE_ENGINE_START_CANCEL")
+	if err != nil {
+		return nil, err
+	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
 }
@@ -515,14 +696,17 @@ func (v *Vehicle) EngineStartCancel(ctx context.Context) (chan string, error) {
 // LightsCancel
 // Cancel an ongoing lights operation.
 func (v *Vehicle) LightsCancel(ctx context.Context) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"delay": "0",
 		"vin":   v.Vin,
-		"pin":   v.client.credentials.PIN}
-	reqUrl := MOBILE_API_VERSION + urlToGen(apiURLs["API_LIGHTS_CANCEL"], v.getAPIGen())
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-	if v.getAPIGen() == FEATURE_G1_TELEMATICS {
-		pollingUrl = MOBILE_API_VERSION + apiURLs["API_G1_HORN_LIGHTS_STATUS"]
+		"pin":   pin}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityHornLights, "API_LIGHTS_CANCEL")
+	if err != nil {
+		return nil, err
 	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
@@ -531,14 +715,17 @@ func (v *Vehicle) LightsCancel(ctx context.Context) (chan string, error) {
 // HornLightsCancel
 // Cancel an ongoing horn and lights operation.
 func (v *Vehicle) HornLightsCancel(ctx context.Context) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"delay": "0",
 		"vin":   v.Vin,
-		"pin":   v.client.credentials.PIN}
-	reqUrl := MOBILE_API_VERSION + urlToGen(apiURLs["API_HORN_LIGHTS_CANCEL"], v.getAPIGen())
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-	if v.getAPIGen() == FEATURE_G1_TELEMATICS {
-		pollingUrl = MOBILE_API_VERSION + apiURLs["API_G1_HORN_LIGHTS_STATUS"]
+		"pin":   pin}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityHornLights, "API_HORN_LIGHTS_CANCEL")
+	if err != nil {
+		return nil, err
 	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
@@ -552,95 +739,20 @@ func (v *Vehicle) ChargeOn(ctx context.Context) (chan string, error) {
 		return nil, errors.New("vehicle is not an EV")
 	}
 
-	params := map[string]string{
-		"delay": "0",
-		"vin":   v.Vin,
-		"pin":   v.client.credentials.PIN}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_EV_CHARGE_NOW"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
-// GetEVChargeSettings
-// Retrieves the EV charging settings and schedules.
-func (v *Vehicle) GetEVChargeSettings(ctx context.Context) error {
-	if !v.IsEV() {
-		v.client.logger.Error("vehicle is not an EV")
-		return errors.New("vehicle is not an EV")
-	}
-
-	if err := v.validateSubscriptionAndSession(ctx); err != nil {
-		return err
-	}
-
-	v.ensureVehicleSelected(ctx)
-
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_EV_FETCH_CHARGE_SETTINGS"]
-	resp, err := v.client.execute(ctx, GET, reqUrl, map[string]string{}, false)
-	if err != nil {
-		v.client.logger.Error("error executing GetEVChargeSettings request", "error", err.Error())
-		return err
-	}
-
-	// TODO: Parse and store EV charge settings
-	v.client.logger.Debug("EV charge settings response", "data", string(resp.Data))
-
-	return nil
-}
-
-// SaveEVChargeSettings
-// Saves or updates the EV charging settings.
-func (v *Vehicle) SaveEVChargeSettings(ctx context.Context, settings map[string]string) error {
-	if !v.IsEV() {
-		v.client.logger.Error("vehicle is not an EV")
-		return errors.New("vehicle is not an EV")
-	}
-
-	if err := v.validateSubscriptionAndSession(ctx); err != nil {
-		return err
-	}
-	v.ensureVehicleSelected(ctx)
-
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_EV_SAVE_CHARGE_SETTINGS"]
-	resp, err := v.client.execute(ctx, POST, reqUrl, settings, true)
+	pin, err := v.remotePIN(ctx)
 	if err != nil {
-		v.client.logger.Error("error executing SaveEVChargeSettings request", "error", err.Error())
-		return err
-	}
-
-	v.client.logger.Debug("Save EV charge settings response", "data", string(resp.Data))
-
-	return nil
-}
-
-// DeleteEVChargeSchedule
-// Deletes an EV charging schedule.
-func (v *Vehicle) DeleteEVChargeSchedule(ctx context.Context, scheduleID string) error {
-	if !v.IsEV() {
-		v.client.logger.Error("vehicle is not an EV")
-		return errors.New("vehicle is not an EV")
-	}
-
-	if err := v.validateSubscriptionAndSession(ctx); err != nil {
-		return err
+		return nil, err
 	}
-	v.ensureVehicleSelected(ctx)
-
 	params := map[string]string{
-		"scheduleId": scheduleID,
-	}
-
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_EV_DELETE_CHARGE_SCHEDULE"]
-	resp, err := v.client.execute(ctx, POST, reqUrl, params, true)
+		"delay": "0",
+		"vin":   v.Vin,
+		"pin":   pin}
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityEVCharge, "API_EV_CHARGE_NOW")
 	if err != nil {
-		v.client.logger.Error("error executing DeleteEVChargeSchedule request", "error", err.Error())
-		return err
+		return nil, err
 	}
 
-	v.client.logger.Debug("Delete EV charge schedule response", "data", string(resp.Data))
-
-	return nil
+	return v.actuate(ctx, params, reqUrl, pollingUrl)
 }
 
 // GetLocation retrieves the current location of the vehicle.
@@ -657,23 +769,26 @@ func (v *Vehicle) GetLocation(ctx context.Context, force bool) (chan string, err
 		return nil, ErrSessionExpired
 	}
 
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	var reqUrl, pollingUrl string
 	var params map[string]string
 	if force { // Sends a locate command to the vehicle to get real time position
-		reqUrl = MOBILE_API_VERSION + apiURLs["API_G2_LOCATE_UPDATE"]
-		pollingUrl = MOBILE_API_VERSION + apiURLs["API_G2_LOCATE_STATUS"]
+		reqUrl, pollingUrl, err = v.commandURLs(CapabilityLocate, "API_G2_LOCATE_UPDATE")
+		if err != nil {
+			return nil, err
+		}
 		params = map[string]string{
 			"vin": v.Vin,
-			"pin": v.client.credentials.PIN}
-		if v.getAPIGen() == FEATURE_G1_TELEMATICS {
-			reqUrl = MOBILE_API_VERSION + apiURLs["API_G1_LOCATE_UPDATE"]
-			pollingUrl = MOBILE_API_VERSION + apiURLs["API_G1_LOCATE_STATUS"]
-		}
+			"pin": pin}
 	} else { // Reports the last location the vehicle has reported to Subaru
 		params = map[string]string{
 			"vin": v.Vin,
-			"pin": v.client.credentials.PIN}
-		reqUrl = MOBILE_API_VERSION + urlToGen(apiURLs["API_LOCATE"], v.getAPIGen())
+			"pin": pin}
+		reqUrl = v.endpointURL("API_LOCATE")
 	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
@@ -690,8 +805,8 @@ func (v *Vehicle) GetClimatePresets(ctx context.Context) error {
 	}
 
 	v.ensureVehicleSelected(ctx)
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_FETCH_RES_SUBARU_PRESETS"]
-	resp, err := v.client.execute(ctx, GET, reqUrl, map[string]string{}, false)
+	reqUrl := v.endpointURL("API_G2_FETCH_RES_SUBARU_PRESETS")
+	resp, err := v.execute(ctx, GET, reqUrl, map[string]string{}, false)
 	if err != nil {
 		v.client.logger.Error("error executing GetClimatePresets request", "error", err.Error())
 		return err
@@ -720,8 +835,8 @@ func (v *Vehicle) GetClimateQuickPresets(ctx context.Context) error {
 		return err
 	}
 	v.ensureVehicleSelected(ctx)
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_FETCH_RES_QUICK_START_SETTINGS"]
-	resp, err := v.client.execute(ctx, GET, reqUrl, map[string]string{}, false)
+	reqUrl := v.endpointURL("API_G2_FETCH_RES_QUICK_START_SETTINGS")
+	resp, err := v.execute(ctx, GET, reqUrl, map[string]string{}, false)
 	if err != nil {
 		v.client.logger.Error("error executing GetClimateQuickPresets request", "error", err.Error())
 		return err
@@ -779,8 +894,8 @@ func (v *Vehicle) UpdateClimateQuickPresets(ctx context.Context) error {
 		"airConditionOn":            "false",                              // boolean
 		"startConfiguration":        "START_ENGINE_ALLOW_KEY_IN_IGNITION", // START_ENGINE_ALLOW_KEY_IN_IGNITION | ONLY FOR PHEV > START_CLIMATE_CONTROL_ONLY_ALLOW_KEY_IN_IGNITION
 	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_SAVE_RES_QUICK_START_SETTINGS"]
-	resp, _ := v.client.execute(ctx, POST, reqUrl, params, true)
+	reqUrl := v.endpointURL("API_G2_SAVE_RES_QUICK_START_SETTINGS")
+	resp, _ := v.execute(ctx, POST, reqUrl, params, true)
 
 	v.client.logger.Debug("http request output", "request", "UpdateClimateUserPresets", "body", resp)
 
@@ -794,8 +909,8 @@ func (v *Vehicle) GetClimateUserPresets(ctx context.Context) error {
 		return err
 	}
 	v.ensureVehicleSelected(ctx)
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_FETCH_RES_USER_PRESETS"]
-	resp, err := v.client.execute(ctx, GET, reqUrl, map[string]string{}, false)
+	reqUrl := v.endpointURL("API_G2_FETCH_RES_USER_PRESETS")
+	resp, err := v.execute(ctx, GET, reqUrl, map[string]string{}, false)
 	if err != nil {
 		v.client.logger.Error("error executing GetClimateUserPresets request", "error", err.Error())
 		return err
@@ -851,8 +966,8 @@ func (v *Vehicle) UpdateClimateUserPresets(ctx context.Context) error {
 		// "canEdit":                   "true",
 		// "disabled":                  "false",
 	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_SAVE_RES_SETTINGS"]
-	resp, _ := v.client.execute(ctx, POST, reqUrl, params, false)
+	reqUrl := v.endpointURL("API_G2_SAVE_RES_SETTINGS")
+	resp, _ := v.execute(ctx, POST, reqUrl, params, false)
 
 	v.client.logger.Debug("http request output", "request", "UpdateClimateUserPresets", "body", resp)
 
@@ -872,6 +987,23 @@ func (v *Vehicle) SaveClimateUserPresets(ctx context.Context, presets []ClimateP
 		return errors.New("maximum of 4 user presets allowed")
 	}
 
+	// Ensure all presets have required fields and valid settings
+	for i := range presets {
+		presets[i].PresetType = "userPreset"
+		presets[i].CanEdit = "true"
+		presets[i].Disabled = "false"
+		if presets[i].StartConfiguration == "" {
+			if v.IsEV() {
+				presets[i].StartConfiguration = START_CONFIG_DEFAULT_EV
+			} else {
+				presets[i].StartConfiguration = START_CONFIG_DEFAULT_RES
+			}
+		}
+		if err := v.validateClimateProfile(presets[i]); err != nil {
+			return err
+		}
+	}
+
 	// Validate session before executing the request
 	if !v.client.validateSession(ctx) {
 		v.client.logger.Error("session is not valid; re-authentication required")
@@ -882,18 +1014,6 @@ func (v *Vehicle) SaveClimateUserPresets(ctx context.Context, presets []ClimateP
 		v.selectVehicle(ctx)
 	}
 
-	// Ensure all presets have required fields
-	for i := range presets {
-		presets[i].PresetType = "userPreset"
-		presets[i].CanEdit = "true"
-		presets[i].Disabled = "false"
-		if v.IsEV() {
-			presets[i].StartConfiguration = "START_CLIMATE_CONTROL_ONLY_ALLOW_KEY_IN_IGNITION"
-		} else {
-			presets[i].StartConfiguration = "START_ENGINE_ALLOW_KEY_IN_IGNITION"
-		}
-	}
-
 	// Convert presets to JSON for the request body
 	presetsJSON, err := json.Marshal(presets)
 	if err != nil {
@@ -901,18 +1021,40 @@ func (v *Vehicle) SaveClimateUserPresets(ctx context.Context, presets []ClimateP
 		return err
 	}
 
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_SAVE_RES_SETTINGS"]
+	// The presets are posted as a JSON array, which execute can't send, so this
+	// goes to the G2 endpoint every generation serves rather than through the
+	// generation fallback.
+	reqUrl := MOBILE_API_VERSION + genPath(apiURLs["API_G2_SAVE_RES_SETTINGS"], FEATURE_G2_TELEMATICS)
 	httpClient := v.client.httpC()
 	resp, err := httpClient.R().
+		SetContext(ctx).
 		SetHeader("Content-Type", "application/json").
 		SetBody(presetsJSON).
-		Post(httpClient.BaseURL() + reqUrl)
+		Post(httpClient.BaseURL() + v.client.applyAPIVersion(reqUrl))
 	if err != nil {
 		v.client.logger.Error("error saving climate presets", "error", err.Error())
 		return err
 	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
 
-	v.client.logger.Debug("http request output", "request", "SaveClimateUserPresets", "status", resp.StatusCode())
+	v.client.logger.Debug("http request output", "request", "SaveClimateUserPresets", "status", resp.StatusCode(), "body", string(body))
+	if !resp.IsStatusSuccess() {
+		return fmt.Errorf("saving climate presets failed with status %s", resp.Status())
+	}
+	var r Response
+	if err := json.Unmarshal(body, &r); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if !r.Success {
+		if r.ErrorCode != "" {
+			return ParseAPIError(r.ErrorCode)
+		}
+		return errors.New("saving climate presets failed")
+	}
 
 	// Refresh the presets after saving
 	return v.GetClimateUserPresets(ctx)
@@ -955,10 +1097,9 @@ func (v *Vehicle) DeleteClimateUserPreset(ctx context.Context, presetName string
 	return v.SaveClimateUserPresets(ctx, updatedPresets)
 }
 
-// GetVehicleStatus .
 // updateVehicleFromStatus updates basic vehicle fields from VehicleStatus data.
 func (v *Vehicle) updateVehicleFromStatus(vs *VehicleStatus) {
-	v.EngineState = vs.VehicleStateType
+	v.EngineState = ParseIgnitionState(vs.VehicleStateType)
 	v.Odometer.Miles = vs.OdometerValue
 	v.Odometer.Kilometers = vs.OdometerValueKm
 	v.DistanceToEmpty.Miles = int(vs.DistanceToEmptyFuelMiles)
@@ -973,6 +1114,116 @@ func (v *Vehicle) updateVehicleFromStatus(vs *VehicleStatus) {
 	v.GeoLocation.Latitude = float64(vs.Latitude)
 	v.GeoLocation.Longitude = float64(vs.Longitude)
 	v.GeoLocation.Heading = vs.Heading
+	v.updateOutsideTemp(vs.OutsideTemp)
+	for _, ts := range []struct {
+		name, status string
+	}{
+		{"tire_front_left", vs.TyreStatusFrontLeft},
+		{"tire_front_right", vs.TyreStatusFrontRight},
+		{"tire_rear_left", vs.TyreStatusRearLeft},
+		{"tire_rear_right", vs.TyreStatusRearRight},
+	} {
+		if t, ok := v.Tires[ts.name]; ok && ts.status != "" {
+			t.Status = ts.status
+			v.Tires[ts.name] = t
+		}
+	}
+
+	sys := v.unitSystem()
+	if d, ok := distanceFor(sys, float64(vs.OdometerValue), float64(vs.OdometerValueKm)); ok {
+		v.Odometer.Distance = d
+	}
+	if d, ok := distanceFor(sys, vs.DistanceToEmptyFuelMiles, float64(vs.DistanceToEmptyFuelKilometers)); ok {
+		v.DistanceToEmpty.Distance = d
+	}
+	if f, ok := fuelEconomyFor(sys, vs.AvgFuelConsumptionMpg, vs.AvgFuelConsumptionLitersPer100Kilometers); ok {
+		v.FuelConsumptionAvg.Economy = f
+	}
+}
+
+// updatedAt returns the time the condition was read from the vehicle, or the
+// current time when lastUpdatedTime is missing or malformed.
+func (vc *VehicleCondition) updatedAt() time.Time {
+	for _, layout := range []string{"2006-01-02T15:04:05.000-0700", "2006-01-02T15:04:05-0700", time.RFC3339} {
+		if t, err := time.Parse(layout, vc.LastUpdatedTime); err == nil {
+			return t
+		}
+	}
+	return time.Now()
+}
+
+// updateVehicleFromCondition updates the odometer, range, fuel economy and
+// tire pressures from a condition response, which reports each with its unit.
+// Tire readings older than the ones held are ignored. Callers hold v.mu.
+func (v *Vehicle) updateVehicleFromCondition(vc *VehicleCondition) {
+	sys := v.unitSystem()
+	at := vc.updatedAt()
+	if validReading(float64(vc.Odometer)) {
+		d := Distance{Value: float64(vc.Odometer), Unit: parseDistanceUnit(vc.OdometerUnit)}
+		v.Odometer.Miles, v.Odometer.Kilometers = int(math.Round(d.Miles())), int(math.Round(d.Kilometers()))
+		v.Odometer.Distance = d.For(sys)
+	}
+	if validReading(float64(vc.DistanceToEmptyFuel)) {
+		d := Distance{Value: float64(vc.DistanceToEmptyFuel), Unit: parseDistanceUnit(vc.DistanceToEmptyFuelUnit)}
+		v.DistanceToEmpty.Miles, v.DistanceToEmpty.Kilometers = int(math.Round(d.Miles())), int(math.Round(d.Kilometers()))
+		v.DistanceToEmpty.Distance = d.For(sys)
+	}
+	if validReading(vc.AvgFuelConsumption) {
+		f := FuelEconomy{Value: vc.AvgFuelConsumption, Unit: parseFuelEconomyUnit(vc.AvgFuelConsumptionUnit)}
+		v.FuelConsumptionAvg.MPG, v.FuelConsumptionAvg.LP100Km = f.MPG(), f.LP100Km()
+		v.FuelConsumptionAvg.Economy = f.For(sys)
+	}
+	for _, tp := range []struct {
+		position, subPosition string
+		value                 float64
+		unit                  string
+	}{
+		{"Front", "Left", vc.TirePressureFrontLeft, vc.TirePressureFrontLeftUnit},
+		{"Front", "Right", vc.TirePressureFrontRight, vc.TirePressureFrontRightUnit},
+		{"Rear", "Left", vc.TirePressureRearLeft, vc.TirePressureRearLeftUnit},
+		{"Rear", "Right", vc.TirePressureRearRight, vc.TirePressureRearRightUnit},
+	} {
+		if !validReading(tp.value) || strconv.FormatFloat(tp.value, 'f', -1, 64) == BAD_TIRE_PRESSURE {
+			continue
+		}
+		pn := strings.ToLower("tire_" + tp.position + "_" + tp.subPosition)
+		t, exists := v.Tires[pn]
+		if !exists {
+			t = Tire{Position: tp.position, SubPosition: tp.subPosition}
+		} else if at.Before(t.Updated) {
+			continue
+		}
+		p := Pressure{Value: tp.value, Unit: parsePressureUnit(tp.unit)}
+		t.PressurePsi = int(math.Round(p.PSI()))
+		t.Reading = p.For(sys)
+		t.Updated = at
+		v.Tires[pn] = t
+		v.recordTirePressure(pn, p, t.Updated)
+	}
+	if v.IsEV() && validReading(float64(vc.EvDistanceToEmpty)) {
+		d := Distance{Value: float64(vc.EvDistanceToEmpty), Unit: parseDistanceUnit(vc.EvDistanceToEmptyUnit)}
+		v.EVStatus.DistanceToEmptyMiles, v.EVStatus.DistanceToEmptyKm = int(math.Round(d.Miles())), int(math.Round(d.Kilometers()))
+		v.EVStatus.Range = d.For(sys)
+	}
+}
+
+// updateOutsideTemp records an outside temperature reading (°C). An absent
+// reading leaves the previous one in place; an invalid one marks it invalid.
+// Callers hold v.mu.
+func (v *Vehicle) updateOutsideTemp(n json.Number) {
+	if n == "" {
+		return
+	}
+	c, err := n.Float64()
+	bad, _ := strconv.ParseFloat(BAD_EXTERNAL_TEMP, 64)
+	if err != nil || c == bad {
+		v.OutsideTemp.Valid = false
+		return
+	}
+	v.OutsideTemp.Celsius = c
+	v.OutsideTemp.Fahrenheit = celsiusToFahrenheit(c)
+	v.OutsideTemp.Temperature = TemperatureC(c).For(v.unitSystem())
+	v.OutsideTemp.Valid = true
 }
 
 // updateEVStatusFromStatus updates EV-specific fields if this is an EV.
@@ -985,6 +1236,9 @@ func (v *Vehicle) updateEVStatusFromStatus(vs *VehicleStatus) {
 	v.EVStatus.DistanceToEmptyKm = vs.EvDistanceToEmptyKilometers
 	v.EVStatus.DistanceToEmptyByStateMiles = vs.EvDistanceToEmptyByStateMiles
 	v.EVStatus.DistanceToEmptyByStateKm = vs.EvDistanceToEmptyByStateKilometers
+	if d, ok := distanceFor(v.unitSystem(), float64(vs.EvDistanceToEmptyMiles), float64(vs.EvDistanceToEmptyKilometers)); ok {
+		v.EVStatus.Range = d
+	}
 }
 
 // isPartField checks if a field name represents a parseable vehicle part.
@@ -995,34 +1249,53 @@ func isPartField(name string) bool {
 		strings.HasPrefix(name, "TirePressure")
 }
 
+// GetVehicleStatus retrieves the vehicle status last cached by the MySubaru
+// cloud; use RefreshStatus to have the vehicle report its current state first.
 func (v *Vehicle) GetVehicleStatus(ctx context.Context) error {
-	if err := v.validateSubscriptionAndSession(ctx); err != nil {
+	vs, err := v.fetchVehicleStatus(ctx)
+	if err != nil {
 		return err
 	}
 
+	// Guard the in-memory mutations against concurrent pollers/marshalers.
+	// ensureVehicleSelected and the HTTP request run outside this lock
+	// (they take the lock separately or not at all), so there is no re-entry.
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	defer v.trackChanges()()
+	v.applyVehicleStatus(vs, readingTime(vs.EventDate.Time))
+	return nil
+}
+
+// fetchVehicleStatus requests and parses the vehicle status without applying it.
+func (v *Vehicle) fetchVehicleStatus(ctx context.Context) (*VehicleStatus, error) {
+	if err := v.validateSubscriptionAndSession(ctx); err != nil {
+		return nil, err
+	}
+
 	v.ensureVehicleSelected(ctx)
-	reqUrl := MOBILE_API_VERSION + urlToGen(apiURLs["API_VEHICLE_STATUS"], v.getAPIGen())
-	resp, err := v.client.execute(ctx, GET, reqUrl, map[string]string{}, false)
+	reqUrl := v.endpointURL("API_VEHICLE_STATUS")
+	resp, err := v.execute(ctx, GET, reqUrl, map[string]string{}, false)
 	if err != nil {
 		v.client.logger.Error("error while executing GetVehicleStatus request", "request", "GetVehicleStatus", "error", err.Error())
-		return err
+		return nil, err
 	}
 
 	var vs VehicleStatus
 	if err = json.Unmarshal(resp.Data, &vs); err != nil {
 		v.client.logger.Error("error while parsing json", "request", "GetVehicleStatus", "error", err.Error())
-		return err
+		return nil, err
 	}
+	return &vs, nil
+}
 
-	// Guard the in-memory mutations against concurrent pollers/marshalers.
-	// ensureVehicleSelected and the HTTP request above run outside this lock
-	// (they take the lock separately or not at all), so there is no re-entry.
-	v.mu.Lock()
-	defer v.mu.Unlock()
-	v.updateVehicleFromStatus(&vs)
-	v.updateEVStatusFromStatus(&vs)
+// applyVehicleStatus updates the vehicle from a status response and marks the
+// odometer, fuel and location readings as taken at at. Callers hold v.mu.
+func (v *Vehicle) applyVehicleStatus(vs *VehicleStatus, at time.Time) {
+	v.updateVehicleFromStatus(vs)
+	v.updateEVStatusFromStatus(vs)
 
-	val := reflect.ValueOf(vs)
+	val := reflect.ValueOf(*vs)
 	typeOfS := val.Type()
 	for i := 0; i < val.NumField(); i++ {
 		if isBadValue(val.Field(i).Interface()) {
@@ -1030,11 +1303,22 @@ func (v *Vehicle) GetVehicleStatus(ctx context.Context) error {
 		}
 		name := typeOfS.Field(i).Name
 		if isPartField(name) {
-			v.parseParts(name, val.Field(i).Interface())
+			v.parseParts(name, val.Field(i).Interface(), at)
 		}
 	}
+	v.Odometer.Updated = at
+	v.DistanceToEmpty.Updated = at
+	v.GeoLocation.Updated = CustomTime1{Time: at}
 	v.Updated = time.Now()
-	return nil
+}
+
+// readingTime returns t, the time a report says it was taken, or the current
+// time when the report doesn't say.
+func readingTime(t time.Time) time.Time {
+	if t.IsZero() {
+		return time.Now()
+	}
+	return t
 }
 
 func isBadValue(val any) bool {
@@ -1072,8 +1356,8 @@ func (v *Vehicle) GetVehicleCondition(ctx context.Context) error {
 		return err
 	}
 	v.ensureVehicleSelected(ctx)
-	reqUrl := MOBILE_API_VERSION + urlToGen(apiURLs["API_CONDITION"], v.getAPIGen())
-	resp, err := v.client.execute(ctx, GET, reqUrl, map[string]string{}, false)
+	reqUrl := v.endpointURL("API_CONDITION")
+	resp, err := v.execute(ctx, GET, reqUrl, map[string]string{}, false)
 	if err != nil {
 		v.client.logger.Error("error executing GetVehicleCondition request", "error", err.Error())
 		return err
@@ -1105,19 +1389,27 @@ func (v *Vehicle) GetVehicleCondition(ctx context.Context) error {
 	// Guard the in-memory mutations against concurrent pollers/marshalers.
 	v.mu.Lock()
 	defer v.mu.Unlock()
+	defer v.trackChanges()()
+
+	v.updateOutsideTemp(vc.OutsideTemp)
+	v.updateVehicleFromCondition(&vc)
 
 	// Parse EV-specific fields if this is an EV
 	if v.IsEV() {
-		v.EVStatus.StateOfChargePercent = vc.EvStateOfChargePercent
-		v.EVStatus.IsPluggedIn = vc.EvIsPluggedIn
-		v.EVStatus.ChargerStateType = vc.EvChargerStateType
+		if soc, err := vc.EvStateOfChargePercent.Float64(); err == nil {
+			v.EVStatus.StateOfChargePercent = int(soc)
+		}
+		v.EVStatus.IsPluggedIn = evPluggedIn(vc.EvIsPluggedIn)
+		v.EVStatus.ChargerStateType = ParseChargerState(vc.EvChargerStateType)
 		v.EVStatus.StateOfChargeMode = vc.EvStateOfChargeMode
 		v.EVStatus.TimeToFullyCharged = vc.EvTimeToFullyCharged
+		v.EVStatus.TimeToFullyChargedUTC = vc.EvTimeToFullyChargedUTC
 	}
 
 	val := reflect.ValueOf(vc)
 	typeOfS := val.Type()
 
+	at := vc.updatedAt()
 	for i := 0; i < val.NumField(); i++ {
 		// v.client.logger.Debug("vehicle condition >> parsing a car part", "field", typeOfS.Field(i).Name, "value", val.Field(i).Interface(), "type", val.Field(i).Type())
 		if isBadValue(val.Field(i).Interface()) {
@@ -1128,7 +1420,7 @@ func (v *Vehicle) GetVehicleCondition(ctx context.Context) error {
 		// Lock status must come from vehicleStatus; condition endpoint is position-only.
 		if strings.HasPrefix(name, "Door") && strings.HasSuffix(name, "Position") ||
 			strings.HasPrefix(name, "Window") && strings.HasSuffix(name, "Status") {
-			v.parseParts(name, val.Field(i).Interface())
+			v.parseParts(name, val.Field(i).Interface(), at)
 		}
 		// if strings.HasPrefix(name, "TirePressure") {
 		// 	v.parseParts(name, val.Field(i).Interface())
@@ -1151,6 +1443,10 @@ func (v *Vehicle) GetVehicleHealth(ctx context.Context) error {
 
 	v.mu.Lock()
 	defer v.mu.Unlock()
+	defer v.trackChanges()()
+	if v.Troubles == nil {
+		v.Troubles = make(map[string]Trouble)
+	}
 	for i, vhi := range vh.VehicleHealthItems {
 		// v.client.logger.Debug("vehicle health item", "id", i, "item", vhi)
 		if vhi.IsTrouble {
@@ -1185,17 +1481,65 @@ func (v *Vehicle) GetFeaturesList() {
 // Cancelling ctx stops the polling goroutine; pass a context that outlives the
 // command (not a short per-request one) if polling should run to completion.
 func (v *Vehicle) actuate(ctx context.Context, params map[string]string, reqUrl, pollingUrl string) (chan string, error) {
+	ctx, err := v.authorize(ctx, params, reqUrl)
+	if err != nil {
+		return nil, err
+	}
+	if l := v.client.dryRunLog(ctx); l != nil {
+		if _, err := v.simulate(ctx, l, params, reqUrl, pollingUrl); err != nil {
+			return nil, err
+		}
+		return simulated(), nil
+	}
 	// Buffer for every possible state emission (one per polling attempt) so the
 	// goroutine never blocks on send — even if the caller stops draining after
 	// its own timeout — which would otherwise leak the goroutine.
 	ch := make(chan string, MaxServiceRequestAttempts+1)
+	v.commandsInFlight.Add(1)
 	go func() {
 		defer close(ch)
+		defer v.commandsInFlight.Add(-1)
 		v.executeServiceRequest(ctx, params, reqUrl, pollingUrl, ch, 1)
 	}()
 	return ch, nil
 }
 
+// actuateChecked works like actuate but posts the request before returning,
+// so a rejected command (a negative acknowledgement, invalid PIN, ...) comes
+// back as the error instead of an "error" state on the channel.
+func (v *Vehicle) actuateChecked(ctx context.Context, params map[string]string, reqUrl, pollingUrl string) (chan string, error) {
+	ctx, err := v.authorize(ctx, params, reqUrl)
+	if err != nil {
+		return nil, err
+	}
+	if l := v.client.dryRunLog(ctx); l != nil {
+		if _, err := v.simulate(ctx, l, params, reqUrl, pollingUrl); err != nil {
+			return nil, err
+		}
+		return simulated(), nil
+	}
+	if err := v.validateSubscriptionAndSession(ctx); err != nil {
+		releaseCommand(ctx)
+		return nil, err
+	}
+	v.ensureVehicleSelected(ctx)
+
+	resp, err := v.execute(ctx, POST, reqUrl, params, true)
+	if err != nil {
+		releaseCommand(ctx)
+		v.client.logger.Error("error while executing service request", "request", reqUrl, "error", err.Error())
+		return nil, err
+	}
+	ch := make(chan string, MaxServiceRequestAttempts+1)
+	v.commandsInFlight.Add(1)
+	go func() {
+		defer close(ch)
+		defer v.commandsInFlight.Add(-1)
+		v.handleServiceResponse(ctx, resp, reqUrl, pollingUrl, ch, 1)
+	}()
+	return ch, nil
+}
+
 // executeServiceRequest
 // Executes a service request to the Subaru API and handles the response.
 func (v *Vehicle) executeServiceRequest(ctx context.Context, params map[string]string, reqUrl, pollingUrl string, ch chan string, attempt int) error {
@@ -1208,6 +1552,9 @@ func (v *Vehicle) executeServiceRequest(ctx context.Context, params map[string]s
 	// Check subscription + session. On failure, emit a terminal state so a caller
 	// blocked on the channel sees the failure instead of a silent empty close.
 	if err := v.validateSubscriptionAndSession(ctx); err != nil {
+		if attempt == 1 {
+			releaseCommand(ctx)
+		}
 		ch <- "error"
 		return err
 	}
@@ -1216,30 +1563,54 @@ func (v *Vehicle) executeServiceRequest(ctx context.Context, params map[string]s
 	var resp *Response
 	var err error
 	if attempt == 1 {
-		resp, err = v.client.execute(ctx, POST, reqUrl, params, true)
+		resp, err = v.execute(ctx, POST, reqUrl, params, true)
 		if err != nil {
+			releaseCommand(ctx)
 			v.client.logger.Error("error while executing service request", "request", reqUrl, "error", err.Error())
 			ch <- "error"
 			return err
 		}
 	} else {
-		resp, err = v.client.execute(ctx, GET, pollingUrl, params, false)
+		resp, err = v.execute(ctx, GET, pollingUrl, params, false)
 		if err != nil {
 			v.client.logger.Error("error while executing service request status polling", "request", reqUrl, "error", err.Error())
 			ch <- "error"
 			return err
 		}
 	}
+	return v.handleServiceResponse(ctx, resp, reqUrl, pollingUrl, ch, attempt)
+}
 
+// handleServiceResponse reports the state of a service request response on ch
+// and keeps polling until the request is finished.
+func (v *Vehicle) handleServiceResponse(ctx context.Context, resp *Response, reqUrl, pollingUrl string, ch chan string, attempt int) error {
+	// The vehicle never accepted a command whose first response failed.
+	rejected := func() {
+		if attempt == 1 {
+			releaseCommand(ctx)
+		}
+	}
 	// dataName field has the list of the states [ remoteServiceStatus | errorResponse ]
 	if resp.DataName == "remoteServiceStatus" {
 		if sr, ok := v.parseServiceRequest([]byte(resp.Data)); ok {
+			// A failed request carries its error code (SXM* on G1) in the
+			// service request rather than in the response envelope.
+			if !sr.Success && sr.ErrorCode != "" {
+				rejected()
+				err := v.client.parseServiceError(sr.ErrorCode, sr.ErrorDescription)
+				v.client.logger.Error("remote service request failed", "request", reqUrl, "errorCode", sr.ErrorCode, "error", err.Error())
+				ch <- "error"
+				return err
+			}
 			ch <- sr.RemoteServiceState
 			switch sr.RemoteServiceState {
 
 			case "finished":
 				// Finished RemoteServiceState Service Request does not include Service Request ID
 				v.client.logger.Debug("Remote service request completed successfully")
+				if sr.Success {
+					v.client.pinAccepted()
+				}
 
 			case "started":
 				if err := sleepCtx(ctx, ServiceRequestPollDelay); err != nil {
@@ -1263,12 +1634,56 @@ func (v *Vehicle) executeServiceRequest(ctx context.Context, params map[string]s
 			}
 			return nil
 		}
+		rejected()
 		v.client.logger.Error("error while parsing service request json", "request", reqUrl, "response", resp.Data)
 		return errors.New("error while parsing service request json")
 	}
+	rejected()
 	return errors.New("response is not a service request")
 }
 
+// sendServiceRequest runs the command preamble and posts a remote service
+// request once, returning the parsed service request (with its ID) instead of
+// polling it to completion. Used where the caller manages the request itself,
+// such as FindMyCar stopping each burst by ID.
+func (v *Vehicle) sendServiceRequest(ctx context.Context, params map[string]string, reqUrl string) (*ServiceRequest, error) {
+	ctx, err := v.authorize(ctx, params, reqUrl)
+	if err != nil {
+		return nil, err
+	}
+	if l := v.client.dryRunLog(ctx); l != nil {
+		return v.simulate(ctx, l, params, reqUrl, "")
+	}
+	if err := v.validateSubscriptionAndSession(ctx); err != nil {
+		releaseCommand(ctx)
+		return nil, err
+	}
+	v.ensureVehicleSelected(ctx)
+
+	resp, err := v.execute(ctx, POST, reqUrl, params, true)
+	if err != nil {
+		releaseCommand(ctx)
+		v.client.logger.Error("error while executing service request", "request", reqUrl, "error", err.Error())
+		return nil, err
+	}
+	if resp.DataName != "remoteServiceStatus" {
+		releaseCommand(ctx)
+		return nil, errors.New("response is not a service request")
+	}
+	sr, ok := v.parseServiceRequest(resp.Data)
+	if !ok {
+		releaseCommand(ctx)
+		return nil, errors.New("error while parsing service request json")
+	}
+	if !sr.Success && sr.ErrorCode != "" {
+		releaseCommand(ctx)
+		err := v.client.parseServiceError(sr.ErrorCode, sr.ErrorDescription)
+		v.client.logger.Error("remote service request failed", "request", reqUrl, "errorCode", sr.ErrorCode, "error", err.Error())
+		return nil, err
+	}
+	return &sr, nil
+}
+
 // parseServiceRequest parses the JSON response from a service request into a ServiceRequest struct.
 // Returns the parsed ServiceRequest and a boolean indicating success.
 func (v *Vehicle) parseServiceRequest(b []byte) (ServiceRequest, bool) {
@@ -1291,13 +1706,16 @@ func (v *Vehicle) selectVehicle(ctx context.Context) {
 			return
 		}
 		v.mu.Lock()
+		publish := v.trackChanges()
 		v.SubscriptionStatus = vData.SubscriptionStatus
+		v.StolenVehicle = vData.StolenVehicle
 		v.GeoLocation.Latitude = vData.VehicleGeoPosition.Latitude
 		v.GeoLocation.Longitude = vData.VehicleGeoPosition.Longitude
 		v.GeoLocation.Heading = vData.VehicleGeoPosition.Heading
 		v.GeoLocation.Speed = vData.VehicleGeoPosition.Speed
 		v.GeoLocation.Updated = vData.VehicleGeoPosition.Timestamp
 		v.Updated = time.Now()
+		publish()
 		v.mu.Unlock()
 	}
 }
@@ -1305,13 +1723,19 @@ func (v *Vehicle) selectVehicle(ctx context.Context) {
 // getAPIGen returns the Subaru telematics API generation (g1, g2, g3) for this vehicle
 // based on the features present in the vehicle configuration.
 func (v *Vehicle) getAPIGen() string {
-	if slices.Contains(v.Features, FEATURE_G1_TELEMATICS) {
+	return apiGenFromFeatures(v.Features)
+}
+
+// apiGenFromFeatures returns the telematics generation advertised by a list of
+// feature codes, for callers that only hold VehicleData (e.g. Client.RemoteUnlock).
+func apiGenFromFeatures(features []string) string {
+	if slices.Contains(features, FEATURE_G1_TELEMATICS) {
 		return "g1"
 	}
-	if slices.Contains(v.Features, FEATURE_G2_TELEMATICS) {
+	if slices.Contains(features, FEATURE_G2_TELEMATICS) {
 		return "g2"
 	}
-	if slices.Contains(v.Features, FEATURE_G3_TELEMATICS) {
+	if slices.Contains(features, FEATURE_G3_TELEMATICS) {
 		return "g3"
 	}
 	return "unknown"
@@ -1322,6 +1746,22 @@ func (v *Vehicle) IsEV() bool {
 	return slices.Contains(v.Features, FEATURE_PHEV)
 }
 
+// Location returns the vehicle's time zone as reported by the API, falling back
+// to the local time zone when it is unknown or can't be loaded.
+func (v *Vehicle) Location() *time.Location {
+	v.mu.RLock()
+	tz := v.TimeZone
+	v.mu.RUnlock()
+	if tz == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
 // getRemoteOptionsStatus returns true if this vehicle has remote service options available
 // (requires appropriate subscription features).
 func (v *Vehicle) getRemoteOptionsStatus() bool {
@@ -1330,7 +1770,8 @@ func (v *Vehicle) getRemoteOptionsStatus() bool {
 
 // parseParts parses vehicle component data from API responses and updates the corresponding
 // vehicle structures (doors, windows, tires) based on the field name and value.
-func (v *Vehicle) parseParts(name string, value any) {
+// at is the time the value was read from the vehicle.
+func (v *Vehicle) parseParts(name string, value any, at time.Time) {
 	re := regexp.MustCompile(`([Dd]oor|[Ww]indow|[Tt]ire)(?:[Pp]ressure)?([Ff]ront|[Rr]ear|[Bb]oot|[Ee]ngine[Hh]ood|[Ss]unroof)([Ll]eft|[Rr]ight)?([Pp]osition|[Ss]tatus|[Ll]ock[Ss]tatus|[Pp]si)?`)
 	grps := re.FindStringSubmatch(name)
 
@@ -1350,7 +1791,7 @@ func (v *Vehicle) parseParts(name string, value any) {
 	case "window":
 		v.parseWindow(pn, grps, value)
 	case "tire":
-		v.parseTire(pn, grps, value)
+		v.parseTire(pn, grps, value, at)
 	}
 }
 
@@ -1375,13 +1816,12 @@ func (v *Vehicle) applyDoorValue(d *Door, fieldType string, value any) {
 	if !ok {
 		return
 	}
-	normalized := strings.ToUpper(strings.TrimSpace(s))
 
 	switch fieldType {
 	case "Position":
-		d.Status = normalized
+		d.Status = ParseDoorStatus(s)
 	case "LockStatus":
-		d.Lock = normalized
+		d.Lock = ParseLockStatus(s)
 	}
 }
 
@@ -1393,9 +1833,9 @@ func (v *Vehicle) applyDoorValue(d *Door, fieldType string, value any) {
 // preserved (GetVehicleStatus skips empty/UNKNOWN/NOT_EQUIPPED lock fields).
 // It is safe for concurrent use.
 func (v *Vehicle) SetDoorLocks(locked bool) {
-	lockState := "UNLOCKED"
+	lockState := DoorUnlocked
 	if locked {
-		lockState = "LOCKED"
+		lockState = DoorLocked
 	}
 	v.mu.Lock()
 	defer v.mu.Unlock()
@@ -1407,14 +1847,14 @@ func (v *Vehicle) SetDoorLocks(locked bool) {
 }
 
 // DoorLocks returns a snapshot copy of each door's reported lock state
-// (e.g. "LOCKED"/"UNLOCKED"); doors with no reported lock value are omitted.
+// (DoorLocked/DoorUnlocked); doors with no reported lock value are omitted.
 // It is safe for concurrent use.
-func (v *Vehicle) DoorLocks() map[string]string {
+func (v *Vehicle) DoorLocks() map[string]LockStatus {
 	v.mu.RLock()
 	defer v.mu.RUnlock()
-	out := make(map[string]string, len(v.Doors))
+	out := make(map[string]LockStatus, len(v.Doors))
 	for name, d := range v.Doors {
-		if d.Lock != "" {
+		if d.Lock != LockStatusUnknown {
 			out[name] = d.Lock
 		}
 	}
@@ -1429,12 +1869,11 @@ func (v *Vehicle) LockState() (locked bool, known bool) {
 	v.mu.RLock()
 	defer v.mu.RUnlock()
 	for _, d := range v.Doors {
-		val := strings.ToUpper(strings.TrimSpace(d.Lock))
-		if val == "" || val == "UNKNOWN" || val == "NOT_EQUIPPED" {
+		if d.Lock == LockStatusUnknown {
 			continue
 		}
 		known = true
-		if val != "LOCKED" {
+		if d.Lock != DoorLocked {
 			return false, true
 		}
 	}
@@ -1452,29 +1891,42 @@ func (v *Vehicle) parseWindow(pn string, grps []string, value any) {
 	}
 
 	if s, ok := value.(string); ok {
-		w.Status = s
+		w.Status = ParseWindowStatus(s)
 	}
 	w.Updated = time.Now()
 	v.Windows[pn] = w
 }
 
-// parseTire handles tire-specific parsing logic.
-func (v *Vehicle) parseTire(pn string, grps []string, value any) {
+// parseTire handles tire-specific parsing logic. A reading older than the
+// tire's current one, such as a stale cached status, is ignored.
+func (v *Vehicle) parseTire(pn string, grps []string, value any, at time.Time) {
 	t, exists := v.Tires[pn]
 	if !exists {
 		t = Tire{
 			Position:    grps[2],
 			SubPosition: grps[3],
 		}
+	} else if at.Before(t.Updated) {
+		return
 	}
 
+	t.Updated = at
 	pressure := toInt(value)
-	if grps[4] == "Psi" {
+	switch {
+	case grps[4] == "Psi":
 		t.PressurePsi = pressure
-	} else {
+		if t.Pressure == 0 {
+			t.Reading = PressurePSI(float64(pressure)).For(v.unitSystem())
+			v.recordTirePressure(pn, PressurePSI(float64(pressure)), t.Updated)
+		}
+	case strconv.Itoa(pressure) == BAD_TIRE_PRESSURE:
+		// The sensor has no reading; don't keep reporting the last one.
+		t.Pressure, t.Reading = 0, Pressure{}
+	default:
 		t.Pressure = pressure
+		t.Reading = pressureFromRaw(pressure).For(v.unitSystem())
+		v.recordTirePressure(pn, pressureFromRaw(pressure), t.Updated)
 	}
-	t.Updated = time.Now()
 	v.Tires[pn] = t
 }
 
@@ -1489,230 +1941,6 @@ func toInt(value any) int {
 	return 0
 }
 
-// SetGeoFence sets up a geofence for the vehicle with specified parameters.
-// This is a G2-only feature that requires Safety Plus subscription.
-// Parameters:
-//   - latitude: Center latitude of the geofence
-//   - longitude: Center longitude of the geofence
-//   - radius: Radius in meters (typically 100-1000)
-//   - name: Name for the geofence
-//   - enabled: Whether the geofence is active
-//   - entryAlert: Alert when vehicle enters the geofence
-//   - exitAlert: Alert when vehicle exits the geofence
-func (v *Vehicle) SetGeoFence(ctx context.Context, latitude, longitude float64, radius int, name string, enabled, entryAlert, exitAlert bool) (chan string, error) {
-	if !slices.Contains(v.Features, FEATURE_G2_TELEMATICS) {
-		return nil, errors.New("geofence feature requires G2 telematics")
-	}
-
-	if !slices.Contains(v.SubscriptionFeatures, FEATURE_SAFETY) {
-		return nil, errors.New("geofence feature requires Safety Plus subscription")
-	}
-
-	// Validate input parameters
-	if err := ValidateCoordinates(latitude, longitude); err != nil {
-		return nil, fmt.Errorf("invalid coordinates: %w", err)
-	}
-
-	if err := ValidateGeoFenceRadius(radius); err != nil {
-		return nil, fmt.Errorf("invalid radius: %w", err)
-	}
-
-	if name == "" {
-		return nil, errors.New("geofence name cannot be empty")
-	}
-
-	params := map[string]string{
-		"delay":      "0",
-		"vin":        v.Vin,
-		"pin":        v.client.credentials.PIN,
-		"latitude":   fmt.Sprintf("%.6f", latitude),
-		"longitude":  fmt.Sprintf("%.6f", longitude),
-		"radius":     strconv.Itoa(radius),
-		"name":       name,
-		"enabled":    strconv.FormatBool(enabled),
-		"entryAlert": strconv.FormatBool(entryAlert),
-		"exitAlert":  strconv.FormatBool(exitAlert),
-	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_GEOFENCE"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
-// UpdateGeoFence updates an existing geofence with new parameters.
-// Parameters:
-//   - fenceId: ID of the geofence to update
-//   - latitude: New center latitude (optional, use 0 to keep current)
-//   - longitude: New center longitude (optional, use 0 to keep current)
-//   - radius: New radius in meters (optional, use 0 to keep current)
-//   - name: New name (optional, use empty string to keep current)
-//   - enabled: New enabled status
-//   - entryAlert: New entry alert setting
-//   - exitAlert: New exit alert setting
-func (v *Vehicle) UpdateGeoFence(ctx context.Context, fenceId string, latitude, longitude float64, radius int, name string, enabled, entryAlert, exitAlert bool) (chan string, error) {
-	if !slices.Contains(v.Features, FEATURE_G2_TELEMATICS) {
-		return nil, errors.New("geofence feature requires G2 telematics")
-	}
-
-	if !slices.Contains(v.SubscriptionFeatures, FEATURE_SAFETY) {
-		return nil, errors.New("geofence feature requires Safety Plus subscription")
-	}
-
-	params := map[string]string{
-		"delay":      "0",
-		"vin":        v.Vin,
-		"pin":        v.client.credentials.PIN,
-		"fenceId":    fenceId,
-		"enabled":    strconv.FormatBool(enabled),
-		"entryAlert": strconv.FormatBool(entryAlert),
-		"exitAlert":  strconv.FormatBool(exitAlert),
-	}
-
-	// Only include optional parameters if they are provided
-	if latitude != 0 {
-		params["latitude"] = fmt.Sprintf("%.6f", latitude)
-	}
-	if longitude != 0 {
-		params["longitude"] = fmt.Sprintf("%.6f", longitude)
-	}
-	if radius != 0 {
-		params["radius"] = strconv.Itoa(radius)
-	}
-	if name != "" {
-		params["name"] = name
-	}
-
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_GEOFENCE"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
-// DeleteGeoFence removes a geofence from the vehicle.
-// Parameters:
-//   - fenceId: ID of the geofence to delete
-func (v *Vehicle) DeleteGeoFence(ctx context.Context, fenceId string) (chan string, error) {
-	if !slices.Contains(v.Features, FEATURE_G2_TELEMATICS) {
-		return nil, errors.New("geofence feature requires G2 telematics")
-	}
-
-	if !slices.Contains(v.SubscriptionFeatures, FEATURE_SAFETY) {
-		return nil, errors.New("geofence feature requires Safety Plus subscription")
-	}
-
-	params := map[string]string{
-		"delay":   "0",
-		"vin":     v.Vin,
-		"pin":     v.client.credentials.PIN,
-		"fenceId": fenceId,
-		"delete":  "true",
-	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_GEOFENCE"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
-// GetGeoFenceStatus retrieves the current status of all geofences for the vehicle.
-// This method fetches information about active geofences and their current status.
-func (v *Vehicle) GetGeoFenceStatus(ctx context.Context) error {
-	if !slices.Contains(v.Features, FEATURE_G2_TELEMATICS) {
-		return errors.New("geofence feature requires G2 telematics")
-	}
-
-	if !slices.Contains(v.SubscriptionFeatures, FEATURE_SAFETY) {
-		return errors.New("geofence feature requires Safety Plus subscription")
-	}
-
-	// This would typically make a GET request to retrieve geofence status
-	// For now, we'll implement it as a placeholder that could be expanded
-	// based on the actual API response structure
-	return errors.New("GetGeoFenceStatus not yet implemented - requires API research")
-}
-
-// SetSpeedFence sets up a speed fence for the vehicle.
-// This is a G2-only feature that alerts when the vehicle exceeds a speed limit.
-// Parameters:
-//   - speedLimit: Speed limit in mph
-//   - enabled: Whether the speed fence is active
-//   - persistent: Whether to keep the setting across restarts
-func (v *Vehicle) SetSpeedFence(ctx context.Context, speedLimit int, enabled, persistent bool) (chan string, error) {
-	if !slices.Contains(v.Features, FEATURE_G2_TELEMATICS) {
-		return nil, errors.New("speed fence feature requires G2 telematics")
-	}
-
-	if !slices.Contains(v.SubscriptionFeatures, FEATURE_SAFETY) {
-		return nil, errors.New("speed fence feature requires Safety Plus subscription")
-	}
-
-	// Validate input parameters
-	if err := ValidateSpeedLimit(speedLimit); err != nil {
-		return nil, fmt.Errorf("invalid speed limit: %w", err)
-	}
-
-	params := map[string]string{
-		"delay":      "0",
-		"vin":        v.Vin,
-		"pin":        v.client.credentials.PIN,
-		"speedLimit": strconv.Itoa(speedLimit),
-		"enabled":    strconv.FormatBool(enabled),
-		"persistent": strconv.FormatBool(persistent),
-	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_SPEEDFENCE"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
-// SetCurfew sets up a curfew for the vehicle.
-// This is a G2-only feature that restricts vehicle operation during specified times.
-// Parameters:
-//   - startTime: Start time in HH:MM format (24-hour)
-//   - endTime: End time in HH:MM format (24-hour)
-//   - daysOfWeek: Array of days (0=Sunday, 6=Saturday)
-//   - enabled: Whether the curfew is active
-func (v *Vehicle) SetCurfew(ctx context.Context, startTime, endTime string, daysOfWeek []int, enabled bool) (chan string, error) {
-	if !slices.Contains(v.Features, FEATURE_G2_TELEMATICS) {
-		return nil, errors.New("curfew feature requires G2 telematics")
-	}
-
-	if !slices.Contains(v.SubscriptionFeatures, FEATURE_SAFETY) {
-		return nil, errors.New("curfew feature requires Safety Plus subscription")
-	}
-
-	// Validate input parameters
-	if err := ValidateTimeRange(startTime, endTime); err != nil {
-		return nil, fmt.Errorf("invalid time range: %w", err)
-	}
-
-	if err := ValidateDaysOfWeek(daysOfWeek); err != nil {
-		return nil, fmt.Errorf("invalid days of week: %w", err)
-	}
-
-	// Convert daysOfWeek array to comma-separated string
-	daysStr := ""
-	for i, day := range daysOfWeek {
-		if i > 0 {
-			daysStr += ","
-		}
-		daysStr += strconv.Itoa(day)
-	}
-
-	params := map[string]string{
-		"delay":      "0",
-		"vin":        v.Vin,
-		"pin":        v.client.credentials.PIN,
-		"startTime":  startTime,
-		"endTime":    endTime,
-		"daysOfWeek": daysStr,
-		"enabled":    strconv.FormatBool(enabled),
-	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_CURFEW"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
 // validateSubscriptionAndSession checks if the vehicle has remote options and validates the session
 func (v *Vehicle) validateSubscriptionAndSession(ctx context.Context) error {
 	if !v.getRemoteOptionsStatus() {
@@ -1737,15 +1965,21 @@ func (v *Vehicle) ensureVehicleSelected(ctx context.Context) {
 
 // fetchInto runs the shared preamble of every vehicle-scoped endpoint call —
 // subscription + session check, vehicle selection — executes the request, and
-// unmarshals the response data into out (skipped when out is nil).
+// unmarshals the response data into out (skipped when out is nil). POSTs
+// change the account, so in dry-run mode they are simulated like remote
+// commands and out is left alone.
 func (v *Vehicle) fetchInto(ctx context.Context, method, urlKey string, params map[string]string, j bool, out any) error {
+	reqUrl := v.endpointURL(urlKey)
+	if l := v.client.dryRunLog(ctx); l != nil && method == POST {
+		_, err := v.simulate(ctx, l, params, reqUrl, "")
+		return err
+	}
 	if err := v.validateSubscriptionAndSession(ctx); err != nil {
 		return err
 	}
 	v.ensureVehicleSelected(ctx)
 
-	reqUrl := MOBILE_API_VERSION + apiURLs[urlKey]
-	resp, err := v.client.execute(ctx, method, reqUrl, params, j)
+	resp, err := v.execute(ctx, method, reqUrl, params, j)
 	if err != nil {
 		v.client.logger.Error("error executing request", "endpoint", urlKey, "error", err.Error())
 		return err
@@ -1923,282 +2157,6 @@ func (v *Vehicle) GetModelInfo() (model string, trim string) {
 	return DetectModelFromCode(v.ModelCode)
 }
 
-// =============================================================================
-// Valet Mode
-// =============================================================================
-
-// ValetModeSettings represents the valet mode configuration
-type ValetModeSettings struct {
-	Enabled        bool    `json:"enabled"`
-	SpeedLimit     int     `json:"speedLimit,omitempty"`
-	SpeedUnit      string  `json:"speedUnit,omitempty"` // MPH or KPH
-	GeoFenceOn     bool    `json:"geoFenceOn,omitempty"`
-	GeoFenceLat    float64 `json:"geoFenceLat,omitempty"`
-	GeoFenceLng    float64 `json:"geoFenceLng,omitempty"`
-	GeoFenceRadius int     `json:"geoFenceRadius,omitempty"`
-}
-
-// GetValetModeStatus retrieves the current valet mode status
-func (v *Vehicle) GetValetModeStatus(ctx context.Context) (*ValetModeSettings, error) {
-	return v.fetchValetSettings(ctx, "API_VEHICLE_VALET_STATUS", map[string]string{"vin": v.Vin})
-}
-
-// GetValetModeSettings retrieves the valet mode custom settings
-func (v *Vehicle) GetValetModeSettings(ctx context.Context) (*ValetModeSettings, error) {
-	return v.fetchValetSettings(ctx, "API_G2_VALET_SETTINGS_FETCH", map[string]string{})
-}
-
-// fetchValetSettings fetches a valet configuration, tolerating backends that
-// return the `data` field as a plain JSON string (or null) for vehicles that
-// don't have valet mode provisioned. Those responses are reported as a disabled
-// (zero-value) configuration rather than surfaced as an unmarshal error.
-func (v *Vehicle) fetchValetSettings(ctx context.Context, urlKey string, params map[string]string) (*ValetModeSettings, error) {
-	var raw json.RawMessage
-	if err := v.fetchInto(ctx, GET, urlKey, params, false, &raw); err != nil {
-		return nil, err
-	}
-	if isJSONStringOrNull(raw) {
-		v.client.logger.Debug("valet mode not configured for vehicle; backend returned non-object data",
-			"endpoint", urlKey, "vin", v.Vin, "data", string(raw))
-		return &ValetModeSettings{}, nil
-	}
-	var settings ValetModeSettings
-	if err := json.Unmarshal(raw, &settings); err != nil {
-		v.client.logger.Error("error parsing valet settings", "endpoint", urlKey, "error", err.Error())
-		return nil, err
-	}
-	return &settings, nil
-}
-
-// ValetModeStart enables valet mode on the vehicle
-func (v *Vehicle) ValetModeStart(ctx context.Context) (chan string, error) {
-	params := map[string]string{
-		"delay":  "0",
-		"vin":    v.Vin,
-		"pin":    v.client.credentials.PIN,
-		"action": "start",
-	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_VALET_MODE"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
-// ValetModeStop disables valet mode on the vehicle
-func (v *Vehicle) ValetModeStop(ctx context.Context) (chan string, error) {
-	params := map[string]string{
-		"delay":  "0",
-		"vin":    v.Vin,
-		"pin":    v.client.credentials.PIN,
-		"action": "stop",
-	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_VALET_MODE"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
-// SaveValetModeSettings saves custom valet mode settings
-func (v *Vehicle) SaveValetModeSettings(ctx context.Context, settings ValetModeSettings) (chan string, error) {
-	params := map[string]string{
-		"vin":        v.Vin,
-		"pin":        v.client.credentials.PIN,
-		"speedLimit": strconv.Itoa(settings.SpeedLimit),
-		"speedUnit":  settings.SpeedUnit,
-	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_VALET_SETTINGS_SAVE"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
-// =============================================================================
-// Geo-Fence (Boundary Alerts)
-// =============================================================================
-
-// GeoFenceSettings represents a boundary alert configuration
-type GeoFenceSettings struct {
-	Enabled      bool    `json:"enabled"`
-	Name         string  `json:"name,omitempty"`
-	Latitude     float64 `json:"latitude"`
-	Longitude    float64 `json:"longitude"`
-	Radius       int     `json:"radius"` // in meters
-	AlertOnExit  bool    `json:"alertOnExit"`
-	AlertOnEntry bool    `json:"alertOnEntry"`
-}
-
-// GetGeoFenceSettings retrieves the current geo-fence (boundary alert) settings
-func (v *Vehicle) GetGeoFenceSettings(ctx context.Context) (*GeoFenceSettings, error) {
-	var settings GeoFenceSettings
-	if err := v.fetchInto(ctx, GET, "API_G2_GEOFENCE_FETCH", map[string]string{}, false, &settings); err != nil {
-		return nil, err
-	}
-	return &settings, nil
-}
-
-// SaveGeoFenceSettings saves geo-fence (boundary alert) settings
-func (v *Vehicle) SaveGeoFenceSettings(ctx context.Context, settings GeoFenceSettings) error {
-	params := map[string]string{
-		"vin":          v.Vin,
-		"latitude":     fmt.Sprintf("%f", settings.Latitude),
-		"longitude":    fmt.Sprintf("%f", settings.Longitude),
-		"radius":       strconv.Itoa(settings.Radius),
-		"alertOnExit":  strconv.FormatBool(settings.AlertOnExit),
-		"alertOnEntry": strconv.FormatBool(settings.AlertOnEntry),
-		"enabled":      strconv.FormatBool(settings.Enabled),
-	}
-	if settings.Name != "" {
-		params["name"] = settings.Name
-	}
-	return v.fetchInto(ctx, POST, "API_G2_GEOFENCE_SAVE", params, true, nil)
-}
-
-// ActivateGeoFence activates the geo-fence alert on the vehicle
-func (v *Vehicle) ActivateGeoFence(ctx context.Context) (chan string, error) {
-	params := map[string]string{
-		"vin":    v.Vin,
-		"pin":    v.client.credentials.PIN,
-		"action": "activate",
-	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_GEOFENCE"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_G2_GEOFENCE_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
-// DeactivateGeoFence deactivates the geo-fence alert on the vehicle
-func (v *Vehicle) DeactivateGeoFence(ctx context.Context) (chan string, error) {
-	params := map[string]string{
-		"vin":    v.Vin,
-		"pin":    v.client.credentials.PIN,
-		"action": "deactivate",
-	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_GEOFENCE"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_G2_GEOFENCE_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
-// =============================================================================
-// Speed Fence (Speed Alerts)
-// =============================================================================
-
-// SpeedFenceSettings represents a speed alert configuration
-type SpeedFenceSettings struct {
-	Enabled    bool   `json:"enabled"`
-	SpeedLimit int    `json:"speedLimit"`
-	SpeedUnit  string `json:"speedUnit"` // MPH or KPH
-}
-
-// GetSpeedFenceSettings retrieves the current speed fence (speed alert) settings
-func (v *Vehicle) GetSpeedFenceSettings(ctx context.Context) (*SpeedFenceSettings, error) {
-	var settings SpeedFenceSettings
-	if err := v.fetchInto(ctx, GET, "API_G2_SPEEDFENCE_FETCH", map[string]string{}, false, &settings); err != nil {
-		return nil, err
-	}
-	return &settings, nil
-}
-
-// SaveSpeedFenceSettings saves speed fence (speed alert) settings
-func (v *Vehicle) SaveSpeedFenceSettings(ctx context.Context, settings SpeedFenceSettings) error {
-	params := map[string]string{
-		"vin":        v.Vin,
-		"speedLimit": strconv.Itoa(settings.SpeedLimit),
-		"speedUnit":  settings.SpeedUnit,
-		"enabled":    strconv.FormatBool(settings.Enabled),
-	}
-	return v.fetchInto(ctx, POST, "API_G2_SPEEDFENCE_SAVE", params, true, nil)
-}
-
-// ActivateSpeedFence activates the speed fence alert on the vehicle
-func (v *Vehicle) ActivateSpeedFence(ctx context.Context) (chan string, error) {
-	params := map[string]string{
-		"vin":    v.Vin,
-		"pin":    v.client.credentials.PIN,
-		"action": "activate",
-	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_SPEEDFENCE"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_G2_SPEEDFENCE_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
-// DeactivateSpeedFence deactivates the speed fence alert on the vehicle
-func (v *Vehicle) DeactivateSpeedFence(ctx context.Context) (chan string, error) {
-	params := map[string]string{
-		"vin":    v.Vin,
-		"pin":    v.client.credentials.PIN,
-		"action": "deactivate",
-	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_SPEEDFENCE"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_G2_SPEEDFENCE_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
-// =============================================================================
-// Curfew Alerts
-// =============================================================================
-
-// CurfewSettings represents a curfew (time fence) alert configuration
-type CurfewSettings struct {
-	Enabled   bool     `json:"enabled"`
-	Name      string   `json:"name,omitempty"`
-	StartTime string   `json:"startTime"` // HH:MM format
-	EndTime   string   `json:"endTime"`   // HH:MM format
-	Days      []string `json:"days"`      // SUN, MON, TUE, WED, THU, FRI, SAT
-}
-
-// GetCurfewSettings retrieves the current curfew alert settings
-func (v *Vehicle) GetCurfewSettings(ctx context.Context) (*CurfewSettings, error) {
-	var settings CurfewSettings
-	if err := v.fetchInto(ctx, GET, "API_G2_CURFEW_FETCH", map[string]string{}, false, &settings); err != nil {
-		return nil, err
-	}
-	return &settings, nil
-}
-
-// SaveCurfewSettings saves curfew alert settings
-func (v *Vehicle) SaveCurfewSettings(ctx context.Context, settings CurfewSettings) error {
-	params := map[string]string{
-		"vin":       v.Vin,
-		"startTime": settings.StartTime,
-		"endTime":   settings.EndTime,
-		"days":      strings.Join(settings.Days, ","),
-		"enabled":   strconv.FormatBool(settings.Enabled),
-	}
-	if settings.Name != "" {
-		params["name"] = settings.Name
-	}
-	return v.fetchInto(ctx, POST, "API_G2_CURFEW_SAVE", params, true, nil)
-}
-
-// ActivateCurfew activates the curfew alert on the vehicle
-func (v *Vehicle) ActivateCurfew(ctx context.Context) (chan string, error) {
-	params := map[string]string{
-		"vin":    v.Vin,
-		"pin":    v.client.credentials.PIN,
-		"action": "activate",
-	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_CURFEW"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_G2_CURFEW_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
-// DeactivateCurfew deactivates the curfew alert on the vehicle
-func (v *Vehicle) DeactivateCurfew(ctx context.Context) (chan string, error) {
-	params := map[string]string{
-		"vin":    v.Vin,
-		"pin":    v.client.credentials.PIN,
-		"action": "deactivate",
-	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_CURFEW"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_G2_CURFEW_STATUS"]
-
-	return v.actuate(ctx, params, reqUrl, pollingUrl)
-}
-
 // =============================================================================
 // Trip Tracker / Driving Journal
 // =============================================================================
@@ -2227,26 +2185,38 @@ func (v *Vehicle) GetTrips(ctx context.Context) ([]Trip, error) {
 
 // TripLogStart starts trip logging on the vehicle
 func (v *Vehicle) TripLogStart(ctx context.Context) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"vin":    v.Vin,
-		"pin":    v.client.credentials.PIN,
+		"pin":    pin,
 		"action": "start",
 	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_TRIPLOG_COMMAND"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityTripLog, "API_G2_TRIPLOG_COMMAND")
+	if err != nil {
+		return nil, err
+	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
 }
 
 // TripLogStop stops trip logging on the vehicle
 func (v *Vehicle) TripLogStop(ctx context.Context) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"vin":    v.Vin,
-		"pin":    v.client.credentials.PIN,
+		"pin":    pin,
 		"action": "stop",
 	}
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_TRIPLOG_COMMAND"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_REMOTE_SVC_STATUS"]
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilityTripLog, "API_G2_TRIPLOG_COMMAND")
+	if err != nil {
+		return nil, err
+	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
 }
@@ -2278,9 +2248,13 @@ type POI struct {
 
 // SendPOI sends a Point of Interest (destination) to the vehicle's navigation system
 func (v *Vehicle) SendPOI(ctx context.Context, poi POI) (chan string, error) {
+	pin, err := v.remotePIN(ctx)
+	if err != nil {
+		return nil, err
+	}
 	params := map[string]string{
 		"vin":       v.Vin,
-		"pin":       v.client.credentials.PIN,
+		"pin":       pin,
 		"name":      poi.Name,
 		"latitude":  fmt.Sprintf("%f", poi.Latitude),
 		"longitude": fmt.Sprintf("%f", poi.Longitude),
@@ -2298,8 +2272,10 @@ func (v *Vehicle) SendPOI(ctx context.Context, poi POI) (chan string, error) {
 		params["zip"] = poi.Zip
 	}
 
-	reqUrl := MOBILE_API_VERSION + apiURLs["API_G2_SEND_POI"]
-	pollingUrl := MOBILE_API_VERSION + apiURLs["API_G2_SEND_POI_STATUS"]
+	reqUrl, pollingUrl, err := v.commandURLs(CapabilitySendPOI, "API_G2_SEND_POI")
+	if err != nil {
+		return nil, err
+	}
 
 	return v.actuate(ctx, params, reqUrl, pollingUrl)
 }
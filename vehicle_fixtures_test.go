@@ -2,8 +2,12 @@ package mysubaru
 
 import (
 	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 // TestLockVehicleWithFixtures tests vehicle locking using fixtures
@@ -601,7 +605,8 @@ func TestGeofencingWithFixtures(t *testing.T) {
 	}
 
 	vehicle := vehicles[0]
-	// Enable SAFETY subscription for testing
+	// Geofencing needs G2 telematics and the SAFETY subscription
+	vehicle.Features = append(vehicle.Features, FEATURE_G2_TELEMATICS)
 	vehicle.SubscriptionFeatures = []string{"REMOTE", "SAFETY"}
 
 	// Test GetGeoFenceSettings
@@ -661,7 +666,8 @@ func TestSpeedFencingWithFixtures(t *testing.T) {
 	}
 
 	vehicle := vehicles[0]
-	// Enable SAFETY subscription for testing
+	// Speed and curfew alerts need G2 telematics and the SAFETY subscription
+	vehicle.Features = append(vehicle.Features, FEATURE_G2_TELEMATICS)
 	vehicle.SubscriptionFeatures = []string{"REMOTE", "SAFETY"}
 
 	// Test GetSpeedFenceSettings
@@ -695,6 +701,19 @@ func TestCurfewWithFixtures(t *testing.T) {
 	}
 
 	ts := mockMySubaruApiWithFixtures(t, fixtures)
+	// Capture what SetCurfew posts to the execute endpoint
+	executed := make(chan map[string]string, 1)
+	router := ts.Config.Handler
+	ts.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/curfew/execute.json") {
+			var params map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+				t.Errorf("expected a JSON body, got %v", err)
+			}
+			executed <- params
+		}
+		router.ServeHTTP(w, r)
+	})
 	ts.Start()
 	defer ts.Close()
 
@@ -721,9 +740,34 @@ func TestCurfewWithFixtures(t *testing.T) {
 	}
 
 	vehicle := vehicles[0]
-	// Enable SAFETY subscription for testing
+	// Speed and curfew alerts need G2 telematics and the SAFETY subscription
+	vehicle.Features = append(vehicle.Features, FEATURE_G2_TELEMATICS)
 	vehicle.SubscriptionFeatures = []string{"REMOTE", "SAFETY"}
 
+	// Test SetCurfew keeps the execute endpoint's wire format
+	ch, err := vehicle.SetCurfew(context.Background(), CurfewSettings{
+		Enabled: true,
+		Name:    "School nights",
+		Windows: []CurfewWindow{{Start: "22:00", End: "06:00", Days: []time.Weekday{time.Sunday, time.Monday, time.Thursday}}},
+	})
+	if err != nil {
+		t.Fatalf("expected no error setting curfew, got %v", err)
+	}
+	for range ch {
+	}
+	want := map[string]string{
+		"delay":      "0",
+		"vin":        vehicle.Vin,
+		"pin":        cfg.MySubaru.Credentials.PIN,
+		"startTime":  "22:00",
+		"endTime":    "06:00",
+		"daysOfWeek": "0,1,4",
+		"enabled":    "true",
+	}
+	if got := <-executed; !maps.Equal(got, want) {
+		t.Errorf("expected curfew params %v, got %v", want, got)
+	}
+
 	// Test GetCurfewSettings
 	settings, err := vehicle.GetCurfewSettings(context.Background())
 	if err != nil {
@@ -513,3 +513,38 @@ func TestVehicleMarshalJSON_EVField(t *testing.T) {
 		})
 	}
 }
+
+// TestUnlockDoors_FeatureGate verifies that unlock targets are gated on the
+// vehicle's feature codes before any request is sent.
+func TestUnlockDoors_FeatureGate(t *testing.T) {
+	tests := []struct {
+		name        string
+		features    []string
+		door        UnlockDoor
+		wantErr     bool
+		unsupported bool
+	}{
+		{"all doors", []string{"g2"}, UnlockAllDoors, false, false},
+		{"driver door", []string{"g2"}, UnlockDriverDoor, false, false},
+		{"tailgate with RTGU", []string{"g2", "RTGU"}, UnlockTailgate, false, false},
+		{"tailgate without RTGU", []string{"g2"}, UnlockTailgate, true, true},
+		{"unknown door", []string{"g2", "RTGU"}, UnlockDoor("SUNROOF_CMD"), true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkUnlockDoor(tt.door, tt.features)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkUnlockDoor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if IsUnsupportedFeatureError(err) != tt.unsupported {
+				t.Errorf("IsUnsupportedFeatureError() = %v, want %v", IsUnsupportedFeatureError(err), tt.unsupported)
+			}
+		})
+	}
+
+	v := &Vehicle{Vin: "TEST123456789", Features: []string{"g2"}}
+	if _, err := v.UnlockDoors(context.Background(), UnlockTailgate); !IsUnsupportedFeatureError(err) {
+		t.Errorf("UnlockDoors(tailgate) error = %v, want UnsupportedFeatureError", err)
+	}
+}